@@ -10,6 +10,8 @@ import (
 	"knative.dev/pkg/injection/sharedmain"
 	"knative.dev/pkg/signals"
 
+	"github.com/triggermesh/triggermesh-core/pkg/reconciler/broker"
+	"github.com/triggermesh/triggermesh-core/pkg/reconciler/kafkabroker"
 	"github.com/triggermesh/triggermesh-core/pkg/reconciler/memorybroker"
 	"github.com/triggermesh/triggermesh-core/pkg/reconciler/redisbroker"
 	"github.com/triggermesh/triggermesh-core/pkg/reconciler/trigger"
@@ -28,8 +30,10 @@ func main() {
 	}
 
 	sharedmain.MainWithContext(ctx, "core-controller",
+		broker.NewController,
 		memorybroker.NewController,
 		redisbroker.NewController,
+		kafkabroker.NewController,
 		trigger.NewController,
 	)
 }
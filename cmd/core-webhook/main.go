@@ -0,0 +1,85 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/injection/sharedmain"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/signals"
+	"knative.dev/pkg/webhook"
+	"knative.dev/pkg/webhook/certificates"
+	"knative.dev/pkg/webhook/resourcesemantics"
+	"knative.dev/pkg/webhook/resourcesemantics/defaulting"
+	"knative.dev/pkg/webhook/resourcesemantics/validation"
+
+	"github.com/triggermesh/triggermesh-core/pkg/apis/config"
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+)
+
+// secretName must match the name of the Secret created in the configuration.
+const secretName = "triggermesh-core-webhook-certs"
+
+var types = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
+	eventingv1alpha1.SchemeGroupVersion.WithKind("Broker"):       &eventingv1alpha1.Broker{},
+	eventingv1alpha1.SchemeGroupVersion.WithKind("KafkaBroker"):  &eventingv1alpha1.KafkaBroker{},
+	eventingv1alpha1.SchemeGroupVersion.WithKind("MemoryBroker"): &eventingv1alpha1.MemoryBroker{},
+	eventingv1alpha1.SchemeGroupVersion.WithKind("RedisBroker"):  &eventingv1alpha1.RedisBroker{},
+	eventingv1alpha1.SchemeGroupVersion.WithKind("Trigger"):      &eventingv1alpha1.Trigger{},
+}
+
+// withContext decorates the context passed to SetDefaults and Validate.
+func withContext(ctx context.Context) context.Context {
+	// Broker references at Triggers can be expressed using group instead of apiVersion.
+	return duckv1.KReferenceGroupAllowed(ctx)
+}
+
+// NewDefaultingAdmissionController returns the webhook that sets default
+// values for TriggerMesh core resources.
+func NewDefaultingAdmissionController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+	// The cluster wide defaults are used when resources do not inform them.
+	store := config.NewStore(logging.FromContext(ctx).Named("config-store"))
+	store.WatchConfigs(cmw)
+
+	return defaulting.NewAdmissionController(ctx,
+		"defaulting.webhook.eventing.triggermesh.io",
+		"/defaulting",
+		types,
+		func(ctx context.Context) context.Context {
+			return withContext(store.ToContext(ctx))
+		},
+		true,
+	)
+}
+
+// NewValidationAdmissionController returns the webhook that validates
+// TriggerMesh core resources.
+func NewValidationAdmissionController(ctx context.Context, _ configmap.Watcher) *controller.Impl {
+	return validation.NewAdmissionController(ctx,
+		"validation.webhook.eventing.triggermesh.io",
+		"/resource-validation",
+		types,
+		withContext,
+		true,
+	)
+}
+
+func main() {
+	ctx := webhook.WithOptions(signals.NewContext(), webhook.Options{
+		ServiceName: webhook.NameFromEnv(),
+		Port:        webhook.PortFromEnv(8443),
+		SecretName:  secretName,
+	})
+
+	sharedmain.WebhookMainWithContext(ctx, webhook.NameFromEnv(),
+		certificates.NewController,
+		NewDefaultingAdmissionController,
+		NewValidationAdmissionController,
+	)
+}
@@ -0,0 +1,33 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"os"
+
+	injection "knative.dev/pkg/injection"
+	"knative.dev/pkg/injection/sharedmain"
+	"knative.dev/pkg/signals"
+
+	"github.com/triggermesh/triggermesh-core/pkg/reconciler/knativebroker"
+	"github.com/triggermesh/triggermesh-core/pkg/reconciler/knativetrigger"
+)
+
+// The Knative integration runs apart from the core controller, since it
+// requires Knative Eventing to be installed at the cluster.
+func main() {
+
+	ctx := signals.NewContext()
+
+	ns := os.Getenv("WORKING_NAMESPACE")
+	if len(ns) != 0 {
+		ctx = injection.WithNamespaceScope(ctx, ns)
+	}
+
+	sharedmain.MainWithContext(ctx, "knative-controller",
+		knativebroker.NewRedisBrokerController,
+		knativebroker.NewMemoryBrokerController,
+		knativetrigger.NewController,
+	)
+}
@@ -11,7 +11,13 @@ API_GROUPS := eventing/v1alpha1
 # generates e.g. "PKG/pkg/apis/sources/v1alpha1 PKG/pkg/apis/sources/v1alpha2"
 api-import-paths := $(foreach group,$(API_GROUPS),$(PKG)/pkg/apis/$(group))
 
-generators := deepcopy client lister informer injection
+generators := deepcopy client lister informer injection knative-injection
+
+# Knative API groups for which injection code is generated. The clientset,
+# listers and informers are those shipped with Knative Eventing. Its injection
+# packages are not used since they require a knative.dev/pkg release other
+# than the one this module depends on.
+KNATIVE_API_GROUPS := knative.dev/eventing/pkg/apis/eventing/v1
 
 .PHONY: codegen $(generators)
 
@@ -67,3 +73,14 @@ injection:
 		--versioned-clientset-package $(PKG)/pkg/client/generated/clientset/internalclientset \
 		--listers-package $(PKG)/pkg/client/generated/listers \
 		--external-versions-informers-package $(PKG)/pkg/client/generated/informers/externalversions
+
+knative-injection:
+	@echo "+ Generating injection for Knative $(KNATIVE_API_GROUPS)"
+	@rm -rf pkg/client/generated/knative/injection
+	@go run knative.dev/pkg/codegen/cmd/injection-gen \
+		--go-header-file hack/boilerplate/boilerplate.go.txt \
+		--input-dirs $(subst $(space),$(comma),$(KNATIVE_API_GROUPS)) \
+		--output-package $(PKG)/pkg/client/generated/knative/injection \
+		--versioned-clientset-package knative.dev/eventing/pkg/client/clientset/versioned \
+		--listers-package knative.dev/eventing/pkg/client/listers \
+		--external-versions-informers-package knative.dev/eventing/pkg/client/informers/externalversions
@@ -0,0 +1,43 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// DefaultsConfigName is the name of the ConfigMap that contains the
+	// cluster wide defaults for TriggerMesh core resources.
+	DefaultsConfigName = "config-broker-defaults"
+
+	// DefaultBrokerClassKey is the ConfigMap key that sets the class for
+	// Brokers that do not inform it.
+	DefaultBrokerClassKey = "default-broker-class"
+
+	// DefaultBrokerClass is used when the cluster does not configure a
+	// default class.
+	DefaultBrokerClass = "MemoryBroker"
+)
+
+// Defaults contains the cluster wide defaults for TriggerMesh core resources.
+type Defaults struct {
+	// BrokerClass is the class assigned to Brokers that do not inform it.
+	BrokerClass string
+}
+
+// NewDefaultsFromConfigMap creates Defaults from the ConfigMap contents.
+func NewDefaultsFromConfigMap(cm *corev1.ConfigMap) (*Defaults, error) {
+	d := &Defaults{
+		BrokerClass: DefaultBrokerClass,
+	}
+
+	if class := strings.TrimSpace(cm.Data[DefaultBrokerClassKey]); class != "" {
+		d.BrokerClass = class
+	}
+
+	return d, nil
+}
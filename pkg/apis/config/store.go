@@ -0,0 +1,101 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/system"
+)
+
+type cfgKey struct{}
+
+// Config contains the cluster wide configuration for TriggerMesh core.
+type Config struct {
+	Defaults *Defaults
+}
+
+// FromContext returns the configuration stored at the context, or nil if
+// not present.
+func FromContext(ctx context.Context) *Config {
+	x, ok := ctx.Value(cfgKey{}).(*Config)
+	if ok {
+		return x
+	}
+	return nil
+}
+
+// FromContextOrDefaults returns the configuration stored at the context,
+// or the built-in defaults if not present.
+func FromContextOrDefaults(ctx context.Context) *Config {
+	if cfg := FromContext(ctx); cfg != nil {
+		return cfg
+	}
+
+	defaults, _ := NewDefaultsFromConfigMap(&corev1.ConfigMap{})
+	return &Config{
+		Defaults: defaults,
+	}
+}
+
+// ToContext stores the configuration at the context.
+func ToContext(ctx context.Context, c *Config) context.Context {
+	return context.WithValue(ctx, cfgKey{}, c)
+}
+
+// Store is a typed wrapper around configmap.UntypedStore to handle the
+// TriggerMesh core configuration.
+type Store struct {
+	*configmap.UntypedStore
+}
+
+// NewStore creates a new store of the TriggerMesh core configuration.
+func NewStore(logger configmap.Logger, onAfterStore ...func(name string, value interface{})) *Store {
+	return &Store{
+		UntypedStore: configmap.NewUntypedStore(
+			"triggermesh-core",
+			logger,
+			configmap.Constructors{
+				DefaultsConfigName: NewDefaultsFromConfigMap,
+			},
+			onAfterStore...,
+		),
+	}
+}
+
+// WatchConfigs watches the configuration ConfigMaps. The ConfigMaps are
+// optional, built-in defaults are used when they do not exist.
+func (s *Store) WatchConfigs(w configmap.Watcher) {
+	dw, ok := w.(configmap.DefaultingWatcher)
+	if !ok {
+		s.UntypedStore.WatchConfigs(w)
+		return
+	}
+
+	dw.WatchWithDefault(corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DefaultsConfigName,
+			Namespace: system.Namespace(),
+		},
+	}, s.OnConfigChanged)
+}
+
+// ToContext stores the current configuration at the context.
+func (s *Store) ToContext(ctx context.Context) context.Context {
+	return ToContext(ctx, s.Load())
+}
+
+// Load returns a copy of the current configuration.
+func (s *Store) Load() *Config {
+	cfg := FromContextOrDefaults(context.Background())
+	if d, ok := s.UntypedLoad(DefaultsConfigName).(*Defaults); ok {
+		dc := *d
+		cfg.Defaults = &dc
+	}
+	return cfg
+}
@@ -25,7 +25,8 @@ func GetOwnerBrokers(object metav1.ObjectMetaAccessor) []metav1.OwnerReference {
 }
 
 func IsBrokerKind(kind string) bool {
-	if kind == "RedisBroker" || kind == "MemoryBroker" {
+	switch kind {
+	case "RedisBroker", "MemoryBroker", "KafkaBroker":
 		return true
 	}
 
@@ -10,6 +10,12 @@ const (
 )
 
 var (
+	// BrokersResource represents a TriggerMesh class based Broker
+	BrokersResource = schema.GroupResource{
+		Group:    GroupName,
+		Resource: "brokers",
+	}
+
 	// BrokersResource represents a TriggerMesh Redis Broker
 	RedisBrokersResource = schema.GroupResource{
 		Group:    GroupName,
@@ -21,4 +27,10 @@ var (
 		Group:    GroupName,
 		Resource: "memorybrokers",
 	}
+
+	// BrokersResource represents a TriggerMesh Kafka Broker
+	KafkaBrokersResource = schema.GroupResource{
+		Group:    GroupName,
+		Resource: "kafkabrokers",
+	}
 )
@@ -0,0 +1,31 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+
+	"knative.dev/pkg/apis"
+
+	"github.com/triggermesh/triggermesh-core/pkg/apis/config"
+)
+
+// SetDefaults sets default values for the common Broker parameters.
+func (b *CommonBrokerSpec) SetDefaults(ctx context.Context) {
+	b.Delivery.SetDefaults(ctx)
+}
+
+// SetDefaults sets default values for the Broker.
+func (b *Broker) SetDefaults(ctx context.Context) {
+	b.Spec.SetDefaults(apis.WithinParent(ctx, b.ObjectMeta))
+}
+
+// SetDefaults sets default values for the BrokerSpec.
+func (bs *BrokerSpec) SetDefaults(ctx context.Context) {
+	if bs.Class == "" {
+		bs.Class = BrokerClass(config.FromContextOrDefaults(ctx).Defaults.BrokerClass)
+	}
+
+	bs.Broker.SetDefaults(ctx)
+}
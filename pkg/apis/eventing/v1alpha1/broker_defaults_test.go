@@ -0,0 +1,47 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/triggermesh/triggermesh-core/pkg/apis/config"
+)
+
+func TestBrokerDefaults(t *testing.T) {
+	testCases := map[string]struct {
+		ctx           context.Context
+		class         BrokerClass
+		expectedClass BrokerClass
+	}{
+		"built-in default class": {
+			ctx:           context.Background(),
+			expectedClass: BrokerClassMemory,
+		},
+		"cluster default class": {
+			ctx: config.ToContext(context.Background(), &config.Config{
+				Defaults: &config.Defaults{BrokerClass: "RedisBroker"},
+			}),
+			expectedClass: BrokerClassRedis,
+		},
+		"informed class": {
+			ctx: config.ToContext(context.Background(), &config.Config{
+				Defaults: &config.Defaults{BrokerClass: "RedisBroker"},
+			}),
+			class:         BrokerClassKafka,
+			expectedClass: BrokerClassKafka,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			b := &Broker{Spec: BrokerSpec{Class: tc.class}}
+			b.SetDefaults(tc.ctx)
+			assert.Equal(t, tc.expectedClass, b.Spec.Class)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+const (
+	BrokerConditionReady                            = apis.ConditionReady
+	BrokerConditionBackingBroker apis.ConditionType = "BackingBrokerReady"
+	BrokerConditionAddressable   apis.ConditionType = "Addressable"
+)
+
+var brokerCondSet = apis.NewLivingConditionSet(
+	BrokerConditionBackingBroker,
+	BrokerConditionAddressable,
+)
+
+// GetGroupVersionKind returns GroupVersionKind for Brokers
+func (b *Broker) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("Broker")
+}
+
+// GetStatus retrieves the status of the Broker. Implements the KRShaped interface.
+func (b *Broker) GetStatus() *duckv1.Status {
+	return &b.Status.Status
+}
+
+// GetConditionSet retrieves the condition set for this resource. Implements the KRShaped interface.
+func (b *Broker) GetConditionSet() apis.ConditionSet {
+	return brokerCondSet
+}
+
+// IsReady returns true if the resource is ready overall and the latest spec has been observed.
+func (b *Broker) IsReady() bool {
+	bs := b.Status
+	return bs.ObservedGeneration == b.Generation &&
+		b.GetConditionSet().Manage(&bs).IsHappy()
+}
+
+// GetConditionSet retrieves the condition set for this resource.
+func (bs *BrokerStatus) GetConditionSet() apis.ConditionSet {
+	return brokerCondSet
+}
+
+// GetTopLevelCondition returns the top level Condition.
+func (bs *BrokerStatus) GetTopLevelCondition() *apis.Condition {
+	return bs.GetConditionSet().Manage(bs).GetTopLevelCondition()
+}
+
+// GetCondition returns the condition currently associated with the given type, or nil.
+func (bs *BrokerStatus) GetCondition(t apis.ConditionType) *apis.Condition {
+	return bs.GetConditionSet().Manage(bs).GetCondition(t)
+}
+
+// InitializeConditions sets relevant unset conditions to Unknown state.
+func (bs *BrokerStatus) InitializeConditions() {
+	bs.GetConditionSet().Manage(bs).InitializeConditions()
+}
+
+// SetAddress makes this Broker addressable by setting the URI. It also
+// sets the BrokerConditionAddressable to true.
+func (bs *BrokerStatus) SetAddress(url *apis.URL) {
+	bs.Address.URL = url
+	if url != nil {
+		brokerCondSet.Manage(bs).MarkTrue(BrokerConditionAddressable)
+	} else {
+		brokerCondSet.Manage(bs).MarkFalse(BrokerConditionAddressable, "nil URL", "URL is nil")
+	}
+}
+
+func (bs *BrokerStatus) MarkBackingBrokerFailed(reason, messageFormat string, messageA ...interface{}) {
+	brokerCondSet.Manage(bs).MarkFalse(BrokerConditionBackingBroker, reason, messageFormat, messageA...)
+}
+
+// PropagateBackingBroker reflects the status of the broker that serves this
+// Broker.
+func (bs *BrokerStatus) PropagateBackingBroker(tb ReconcilableBroker) {
+	gvk := tb.GetGroupVersionKind()
+	bs.BackingBroker = &duckv1.KReference{
+		APIVersion: gvk.GroupVersion().String(),
+		Kind:       gvk.Kind,
+		Name:       tb.GetObjectMeta().GetName(),
+		Namespace:  tb.GetObjectMeta().GetNamespace(),
+	}
+
+	tbs := tb.GetReconcilableBrokerStatus()
+	switch c := tbs.GetTopLevelCondition(); {
+	case c == nil:
+		brokerCondSet.Manage(bs).MarkUnknown(BrokerConditionBackingBroker,
+			"BrokerNotReconciled", "%s has not yet been reconciled.", gvk.Kind)
+	case c.IsTrue():
+		brokerCondSet.Manage(bs).MarkTrue(BrokerConditionBackingBroker)
+	case c.IsFalse():
+		brokerCondSet.Manage(bs).MarkFalse(BrokerConditionBackingBroker, c.Reason, c.Message)
+	default:
+		brokerCondSet.Manage(bs).MarkUnknown(BrokerConditionBackingBroker, c.Reason, c.Message)
+	}
+
+	bs.DeadLetterSinkURI = tbs.GetDeadLetterSinkURI()
+	bs.PublicURL = tbs.GetPublicURL()
+	bs.SetAddress(tbs.GetAddress())
+	bs.Addresses = tbs.GetAddresses()
+}
+
+// MemoryBrokerSpec returns the spec of the MemoryBroker that serves the Broker.
+func (bs *BrokerSpec) MemoryBrokerSpec() *MemoryBrokerSpec {
+	return &MemoryBrokerSpec{
+		Memory: bs.Memory,
+		Broker: bs.Broker,
+	}
+}
+
+// RedisBrokerSpec returns the spec of the RedisBroker that serves the Broker.
+func (bs *BrokerSpec) RedisBrokerSpec() *RedisBrokerSpec {
+	return &RedisBrokerSpec{
+		Redis:  bs.Redis,
+		Broker: bs.Broker,
+	}
+}
+
+// KafkaBrokerSpec returns the spec of the KafkaBroker that serves the Broker.
+func (bs *BrokerSpec) KafkaBrokerSpec() *KafkaBrokerSpec {
+	kbs := &KafkaBrokerSpec{
+		Broker: bs.Broker,
+	}
+	if bs.Kafka != nil {
+		kbs.Kafka = *bs.Kafka
+	}
+	return kbs
+}
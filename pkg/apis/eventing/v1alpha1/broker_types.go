@@ -0,0 +1,116 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// +genclient
+// +genreconciler
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Broker is a class based broker that delegates the events management to a
+// broker of the kind selected by its class. Triggers that reference a Broker
+// do not need to know which backend serves it.
+type Broker struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the desired state of the broker.
+	Spec BrokerSpec `json:"spec,omitempty"`
+
+	// Status represents the current state of the broker. This data may be out of
+	// date.
+	// +optional
+	Status BrokerStatus `json:"status,omitempty"`
+}
+
+var (
+	// Make sure this is a kubernetes object.
+	_ runtime.Object = (*Broker)(nil)
+	// Check that the type conforms to the duck Knative Resource shape.
+	_ duckv1.KRShaped = (*Broker)(nil)
+	// Check that the type can be validated and defaulted.
+	_ apis.Validatable = (*Broker)(nil)
+	_ apis.Defaultable = (*Broker)(nil)
+)
+
+// BrokerClass is the kind of broker that serves a Broker.
+type BrokerClass string
+
+// Broker classes match the name of the broker kinds that back them.
+const (
+	BrokerClassMemory BrokerClass = "MemoryBroker"
+	BrokerClassRedis  BrokerClass = "RedisBroker"
+	BrokerClassKafka  BrokerClass = "KafkaBroker"
+)
+
+type BrokerSpec struct {
+	// Class of the broker that serves this Broker. Defaults to the cluster
+	// wide default class.
+	// +optional
+	Class BrokerClass `json:"class,omitempty"`
+
+	// Memory parameters, used by the MemoryBroker class.
+	// +optional
+	Memory *Memory `json:"memory,omitempty"`
+
+	// Redis parameters, used by the RedisBroker class.
+	// +optional
+	Redis *Redis `json:"redis,omitempty"`
+
+	// Kafka parameters, required by the KafkaBroker class.
+	// +optional
+	Kafka *Kafka `json:"kafka,omitempty"`
+
+	Broker CommonBrokerSpec `json:"broker,omitempty"`
+}
+
+// BrokerStatus represents the current state of a Broker.
+type BrokerStatus struct {
+	// inherits duck/v1 Status, which currently provides:
+	// * ObservedGeneration - the 'Generation' of the Broker that was last processed by the controller.
+	// * Conditions - the latest available observations of a resource's current state.
+	duckv1.Status `json:",inline"`
+
+	// Broker is Addressable. It exposes the endpoint as an URI to get events
+	// delivered into the Broker mesh.
+	// +optional
+	Address duckv1.Addressable `json:"address,omitempty"`
+
+	// Addresses lists the addresses the broker can be reached at.
+	// +optional
+	Addresses []duckv1.Addressable `json:"addresses,omitempty"`
+
+	// PublicURL is the address where the broker is reachable from outside
+	// the cluster.
+	// +optional
+	PublicURL *apis.URL `json:"publicURL,omitempty"`
+
+	// DeliveryStatus contains the resolved URL to the broker level dead
+	// letter sink.
+	// +optional
+	eventingduckv1.DeliveryStatus `json:",inline"`
+
+	// BackingBroker references the broker that serves this Broker.
+	// +optional
+	BackingBroker *duckv1.KReference `json:"backingBroker,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// BrokerList is a collection of Brokers.
+type BrokerList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Broker `json:"items"`
+}
@@ -0,0 +1,206 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"knative.dev/pkg/apis"
+)
+
+// Validate the common Broker parameters.
+func (b *CommonBrokerSpec) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	if b.Port != nil && (*b.Port < 1 || *b.Port > 65535) {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(*b.Port, 1, 65535, "port"))
+	}
+
+	if b.Observability != nil && b.Observability.ValueFromConfigMap == "" {
+		errs = errs.Also(apis.ErrMissingField("valueFromConfigMap").ViaField("observability"))
+	}
+
+	if b.Replicas != nil && *b.Replicas < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*b.Replicas, "replicas"))
+	}
+
+	if b.Autoscaling != nil {
+		if b.Replicas != nil {
+			errs = errs.Also(apis.ErrMultipleOneOf("replicas", "autoscaling"))
+		}
+		errs = errs.Also(b.Autoscaling.Validate(ctx).ViaField("autoscaling"))
+	}
+
+	if b.PodTemplate != nil {
+		errs = errs.Also(b.PodTemplate.Validate(ctx).ViaField("podTemplate"))
+	}
+
+	if b.Exposure != nil {
+		errs = errs.Also(b.Exposure.Validate(ctx).ViaField("exposure"))
+	}
+
+	if b.TriggerNamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(b.TriggerNamespaceSelector); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(err.Error(), "triggerNamespaceSelector"))
+		}
+	}
+
+	return errs.Also(validateDelivery(ctx, b.Delivery).ViaField("delivery"))
+}
+
+// Validate the broker autoscaling parameters.
+func (a *Autoscaling) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	if a.MaxReplicas < 1 {
+		errs = errs.Also(apis.ErrInvalidValue(a.MaxReplicas, "maxReplicas"))
+	}
+
+	if a.MinReplicas != nil && (*a.MinReplicas < 1 || *a.MinReplicas > a.MaxReplicas) {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(*a.MinReplicas, 1, a.MaxReplicas, "minReplicas"))
+	}
+
+	if a.TargetCPUUtilizationPercentage != nil && *a.TargetCPUUtilizationPercentage < 1 {
+		errs = errs.Also(apis.ErrInvalidValue(*a.TargetCPUUtilizationPercentage, "targetCPUUtilizationPercentage"))
+	}
+
+	// The API server defaults autoscalers without metrics to a CPU target,
+	// which would be reverted at every reconciliation.
+	if a.TargetCPUUtilizationPercentage == nil && len(a.Metrics) == 0 {
+		errs = errs.Also(apis.ErrMissingOneOf("targetCPUUtilizationPercentage", "metrics"))
+	}
+
+	return errs
+}
+
+// Validate the pod template parameters.
+func (pt *PodTemplate) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	for k, v := range pt.Labels {
+		if msgs := validation.IsQualifiedName(k); len(msgs) != 0 {
+			errs = errs.Also(apis.ErrInvalidKeyName(k, "labels", msgs...))
+		}
+		if msgs := validation.IsValidLabelValue(v); len(msgs) != 0 {
+			errs = errs.Also(apis.ErrInvalidValue(v, apis.CurrentField, strings.Join(msgs, ", ")).ViaKey(k).ViaField("labels"))
+		}
+	}
+
+	for k := range pt.Annotations {
+		if msgs := validation.IsQualifiedName(strings.ToLower(k)); len(msgs) != 0 {
+			errs = errs.Also(apis.ErrInvalidKeyName(k, "annotations", msgs...))
+		}
+	}
+
+	for k, v := range pt.NodeSelector {
+		if msgs := validation.IsQualifiedName(k); len(msgs) != 0 {
+			errs = errs.Also(apis.ErrInvalidKeyName(k, "nodeSelector", msgs...))
+		}
+		if msgs := validation.IsValidLabelValue(v); len(msgs) != 0 {
+			errs = errs.Also(apis.ErrInvalidValue(v, apis.CurrentField, strings.Join(msgs, ", ")).ViaKey(k).ViaField("nodeSelector"))
+		}
+	}
+
+	if pt.PriorityClassName != "" {
+		if msgs := validation.IsDNS1123Subdomain(pt.PriorityClassName); len(msgs) != 0 {
+			errs = errs.Also(apis.ErrInvalidValue(pt.PriorityClassName, "priorityClassName", strings.Join(msgs, ", ")))
+		}
+	}
+
+	return errs
+}
+
+// Validate the Broker.
+func (b *Broker) Validate(ctx context.Context) *apis.FieldError {
+	ctx = apis.WithinParent(ctx, b.ObjectMeta)
+	return b.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec")
+}
+
+// Validate the BrokerSpec.
+func (bs *BrokerSpec) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	// Parameters are validated as part of the broker kind that serves the
+	// class, which shares the same field names.
+	switch bs.Class {
+	case BrokerClassMemory:
+		errs = bs.MemoryBrokerSpec().Validate(ctx)
+	case BrokerClassRedis:
+		errs = bs.RedisBrokerSpec().Validate(ctx)
+	case BrokerClassKafka:
+		if bs.Kafka == nil {
+			errs = apis.ErrMissingField("kafka")
+		} else {
+			errs = bs.KafkaBrokerSpec().Validate(ctx)
+		}
+	default:
+		errs = apis.ErrInvalidValue(bs.Class, "class",
+			"must be one of MemoryBroker, RedisBroker or KafkaBroker")
+	}
+
+	// Parameters that belong to other classes are not allowed.
+	if bs.Memory != nil && bs.Class != BrokerClassMemory {
+		errs = errs.Also(apis.ErrDisallowedFields("memory"))
+	}
+	if bs.Redis != nil && bs.Class != BrokerClassRedis {
+		errs = errs.Also(apis.ErrDisallowedFields("redis"))
+	}
+	if bs.Kafka != nil && bs.Class != BrokerClassKafka {
+		errs = errs.Also(apis.ErrDisallowedFields("kafka"))
+	}
+
+	return errs
+}
+
+// Validate the broker exposure parameters.
+func (e *BrokerExposure) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	switch e.ServiceType {
+	case "", corev1.ServiceTypeClusterIP, corev1.ServiceTypeNodePort, corev1.ServiceTypeLoadBalancer:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(e.ServiceType, "serviceType"))
+	}
+
+	if e.Ingress != nil && e.HTTPRoute != nil {
+		errs = errs.Also(apis.ErrMultipleOneOf("ingress", "httpRoute"))
+	}
+
+	if e.Ingress != nil {
+		errs = errs.Also(validateHostname(e.Ingress.Host).ViaField("ingress", "host"))
+		for k := range e.Ingress.Annotations {
+			if msgs := validation.IsQualifiedName(strings.ToLower(k)); len(msgs) != 0 {
+				errs = errs.Also(apis.ErrInvalidKeyName(k, "annotations", msgs...).ViaField("ingress"))
+			}
+		}
+	}
+
+	if e.HTTPRoute != nil {
+		errs = errs.Also(validateHostname(e.HTTPRoute.Hostname).ViaField("httpRoute", "hostname"))
+		if len(e.HTTPRoute.ParentRefs) == 0 {
+			errs = errs.Also(apis.ErrMissingField("parentRefs").ViaField("httpRoute"))
+		}
+		for i, pr := range e.HTTPRoute.ParentRefs {
+			if pr.Name == "" {
+				errs = errs.Also(apis.ErrMissingField("name").ViaFieldIndex("parentRefs", i).ViaField("httpRoute"))
+			}
+		}
+	}
+
+	return errs
+}
+
+func validateHostname(host string) *apis.FieldError {
+	if host == "" {
+		return apis.ErrMissingField(apis.CurrentField)
+	}
+	if msgs := validation.IsDNS1123Subdomain(host); len(msgs) != 0 {
+		return apis.ErrInvalidValue(host, apis.CurrentField, strings.Join(msgs, ", "))
+	}
+	return nil
+}
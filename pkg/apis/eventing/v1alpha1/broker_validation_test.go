@@ -0,0 +1,566 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/ptr"
+)
+
+func secretValue(name, key string) *SecretValueFromSource {
+	return &SecretValueFromSource{
+		SecretKeyRef: corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: name},
+			Key:                  key,
+		},
+	}
+}
+
+func TestRedisBrokerValidation(t *testing.T) {
+	testCases := map[string]struct {
+		spec          RedisBrokerSpec
+		expectedPaths []string
+	}{
+		"managed redis": {
+			spec: RedisBrokerSpec{},
+		},
+		"valid user provided redis": {
+			spec: RedisBrokerSpec{
+				Redis: &Redis{
+					Connection: &RedisConnection{
+						URL:            ptr.String("redis:6379"),
+						Password:       secretValue("redis", "password"),
+						TLSCertificate: secretValue("redis", "tls.crt"),
+						TLSKey:         secretValue("redis", "tls.key"),
+					},
+					StreamMaxLen: intPtr(0),
+				},
+				Broker: CommonBrokerSpec{Port: intPtr(8080)},
+			},
+		},
+		"url and cluster urls": {
+			spec: RedisBrokerSpec{
+				Redis: &Redis{
+					Connection: &RedisConnection{
+						URL:         ptr.String("redis:6379"),
+						ClusterURLs: []string{"redis-0:6379"},
+					},
+				},
+			},
+			expectedPaths: []string{"spec.redis.connection.clusterURLs", "spec.redis.connection.url"},
+		},
+		"no url": {
+			spec: RedisBrokerSpec{
+				Redis: &Redis{
+					Connection: &RedisConnection{},
+				},
+			},
+			expectedPaths: []string{"spec.redis.connection.clusterURLs", "spec.redis.connection.url"},
+		},
+		"sentinel urls": {
+			spec: RedisBrokerSpec{
+				Redis: &Redis{
+					Connection: &RedisConnection{
+						ClusterURLs: []string{
+							"redis+sentinel://sentinel-0:26379",
+							"redis://sentinel-1:26379?master_name=mymaster",
+							"redis-0:6379",
+						},
+					},
+				},
+			},
+			expectedPaths: []string{"spec.redis.connection.clusterURLs[0]", "spec.redis.connection.clusterURLs[1]"},
+		},
+		"sentinel url scheme": {
+			spec: RedisBrokerSpec{
+				Redis: &Redis{
+					Connection: &RedisConnection{
+						URL: ptr.String("sentinel://sentinel-0:26379"),
+					},
+				},
+			},
+			expectedPaths: []string{"spec.redis.connection.url"},
+		},
+		"host and port addresses named sentinel": {
+			spec: RedisBrokerSpec{
+				Redis: &Redis{
+					Connection: &RedisConnection{
+						ClusterURLs: []string{
+							"my-redis-sentinel:6379",
+							"sentinel-1:6379",
+							"redis://my-redis-sentinel:6379",
+						},
+					},
+				},
+			},
+		},
+		"negative stream max length": {
+			spec: RedisBrokerSpec{
+				Redis: &Redis{
+					StreamMaxLen: intPtr(-1),
+				},
+			},
+			expectedPaths: []string{"spec.redis.streamMaxLen"},
+		},
+		"tls key without certificate": {
+			spec: RedisBrokerSpec{
+				Redis: &Redis{
+					Connection: &RedisConnection{
+						URL:    ptr.String("redis:6379"),
+						TLSKey: secretValue("redis", "tls.key"),
+					},
+				},
+			},
+			expectedPaths: []string{"spec.redis.connection.tlsCertificate"},
+		},
+		"incomplete secret reference": {
+			spec: RedisBrokerSpec{
+				Redis: &Redis{
+					Connection: &RedisConnection{
+						URL:      ptr.String("redis:6379"),
+						Username: secretValue("redis", ""),
+					},
+				},
+			},
+			expectedPaths: []string{"spec.redis.connection.username.secretKeyRef.key"},
+		},
+		"port out of range": {
+			spec: RedisBrokerSpec{
+				Broker: CommonBrokerSpec{Port: intPtr(65536)},
+			},
+			expectedPaths: []string{"spec.broker.port"},
+		},
+		"pod template for user provided redis": {
+			spec: RedisBrokerSpec{
+				Redis: &Redis{
+					Connection: &RedisConnection{
+						URL: ptr.String("redis:6379"),
+					},
+					PodTemplate: &PodTemplate{PriorityClassName: "high"},
+				},
+			},
+			expectedPaths: []string{"spec.redis.podTemplate"},
+		},
+		"persistence for user provided redis": {
+			spec: RedisBrokerSpec{
+				Redis: &Redis{
+					Connection: &RedisConnection{
+						URL: ptr.String("redis:6379"),
+					},
+					Persistence: &RedisPersistence{Size: resource.MustParse("1Gi")},
+				},
+			},
+			expectedPaths: []string{"spec.redis.persistence"},
+		},
+		"deletion policy for user provided redis": {
+			spec: RedisBrokerSpec{
+				Redis: &Redis{
+					Connection: &RedisConnection{
+						URL: ptr.String("redis:6379"),
+					},
+					DeletionPolicy: RedisDeletionPolicyDelete,
+				},
+			},
+		},
+		"deletion policy for managed redis": {
+			spec: RedisBrokerSpec{
+				Redis: &Redis{
+					DeletionPolicy: RedisDeletionPolicyDelete,
+				},
+			},
+			expectedPaths: []string{"spec.redis.deletionPolicy"},
+		},
+		"invalid deletion policy": {
+			spec: RedisBrokerSpec{
+				Redis: &Redis{
+					Connection: &RedisConnection{
+						URL: ptr.String("redis:6379"),
+					},
+					DeletionPolicy: "Orphan",
+				},
+			},
+			expectedPaths: []string{"spec.redis.deletionPolicy"},
+		},
+		"count and age retention combined": {
+			spec: RedisBrokerSpec{
+				Redis: &Redis{
+					StreamMaxLen: intPtr(10000),
+					Retention: &RedisRetention{
+						MaxAge:   ptr.String("P7D"),
+						Exact:    ptr.Bool(true),
+						MaxBytes: resource.NewQuantity(1<<30, resource.BinarySI),
+					},
+				},
+			},
+		},
+		"invalid retention": {
+			spec: RedisBrokerSpec{
+				Redis: &Redis{
+					Retention: &RedisRetention{
+						MaxAge:   ptr.String("7 days"),
+						MaxBytes: resource.NewQuantity(0, resource.BinarySI),
+					},
+				},
+			},
+			expectedPaths: []string{"spec.redis.retention.maxAge", "spec.redis.retention.maxBytes"},
+		},
+		"zero retention age": {
+			spec: RedisBrokerSpec{
+				Redis: &Redis{
+					Retention: &RedisRetention{
+						MaxAge: ptr.String("PT0S"),
+					},
+				},
+			},
+			expectedPaths: []string{"spec.redis.retention.maxAge"},
+		},
+		"invalid persistence": {
+			spec: RedisBrokerSpec{
+				Redis: &Redis{
+					Persistence: &RedisPersistence{AccessMode: corev1.ReadOnlyMany},
+				},
+			},
+			expectedPaths: []string{"spec.redis.persistence.size", "spec.redis.persistence.accessMode"},
+		},
+		"invalid pod template": {
+			spec: RedisBrokerSpec{
+				Broker: CommonBrokerSpec{PodTemplate: &PodTemplate{
+					Labels:            map[string]string{"team": "not a valid value"},
+					NodeSelector:      map[string]string{"not a key": "ssd"},
+					PriorityClassName: "High",
+				}},
+			},
+			expectedPaths: []string{
+				"spec.broker.podTemplate.labels[team]",
+				"spec.broker.podTemplate.nodeSelector",
+				"spec.broker.podTemplate.priorityClassName",
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rb := &RedisBroker{Spec: tc.spec}
+			rb.SetDefaults(context.Background())
+			assertFieldErrorPaths(t, tc.expectedPaths, rb.Validate(context.Background()))
+		})
+	}
+}
+
+func TestMemoryBrokerValidation(t *testing.T) {
+	testCases := map[string]struct {
+		spec          MemoryBrokerSpec
+		expectedPaths []string
+	}{
+		"empty spec": {
+			spec: MemoryBrokerSpec{},
+		},
+		"valid buffer size": {
+			spec: MemoryBrokerSpec{
+				Memory: &Memory{BufferSize: intPtr(100)},
+			},
+		},
+		"negative buffer size": {
+			spec: MemoryBrokerSpec{
+				Memory: &Memory{BufferSize: intPtr(-1)},
+			},
+			expectedPaths: []string{"spec.memory.bufferSize"},
+		},
+		"port out of range": {
+			spec: MemoryBrokerSpec{
+				Broker: CommonBrokerSpec{Port: intPtr(0)},
+			},
+			expectedPaths: []string{"spec.broker.port"},
+		},
+		"missing observability configmap": {
+			spec: MemoryBrokerSpec{
+				Broker: CommonBrokerSpec{Observability: &Observability{}},
+			},
+			expectedPaths: []string{"spec.broker.observability.valueFromConfigMap"},
+		},
+		"valid autoscaling": {
+			spec: MemoryBrokerSpec{
+				Broker: CommonBrokerSpec{Autoscaling: &Autoscaling{
+					MinReplicas:                    ptr.Int32(2),
+					MaxReplicas:                    5,
+					TargetCPUUtilizationPercentage: ptr.Int32(80),
+				}},
+			},
+		},
+		"negative replicas": {
+			spec: MemoryBrokerSpec{
+				Broker: CommonBrokerSpec{Replicas: ptr.Int32(-1)},
+			},
+			expectedPaths: []string{"spec.broker.replicas"},
+		},
+		"replicas and autoscaling": {
+			spec: MemoryBrokerSpec{
+				Broker: CommonBrokerSpec{
+					Replicas: ptr.Int32(2),
+					Autoscaling: &Autoscaling{
+						MaxReplicas:                    5,
+						TargetCPUUtilizationPercentage: ptr.Int32(80),
+					},
+				},
+			},
+			expectedPaths: []string{"spec.broker.autoscaling", "spec.broker.replicas"},
+		},
+		"autoscaling min above max": {
+			spec: MemoryBrokerSpec{
+				Broker: CommonBrokerSpec{Autoscaling: &Autoscaling{
+					MinReplicas:                    ptr.Int32(3),
+					MaxReplicas:                    2,
+					TargetCPUUtilizationPercentage: ptr.Int32(80),
+				}},
+			},
+			expectedPaths: []string{"spec.broker.autoscaling.minReplicas"},
+		},
+		"autoscaling with custom metrics only": {
+			spec: MemoryBrokerSpec{
+				Broker: CommonBrokerSpec{Autoscaling: &Autoscaling{
+					MaxReplicas: 5,
+					Metrics: []autoscalingv2.MetricSpec{{
+						Type: autoscalingv2.PodsMetricSourceType,
+					}},
+				}},
+			},
+		},
+		"autoscaling without target": {
+			spec: MemoryBrokerSpec{
+				Broker: CommonBrokerSpec{Autoscaling: &Autoscaling{
+					MaxReplicas: 5,
+				}},
+			},
+			expectedPaths: []string{
+				"spec.broker.autoscaling.metrics",
+				"spec.broker.autoscaling.targetCPUUtilizationPercentage",
+			},
+		},
+		"autoscaling without max": {
+			spec: MemoryBrokerSpec{
+				Broker: CommonBrokerSpec{Autoscaling: &Autoscaling{
+					TargetCPUUtilizationPercentage: ptr.Int32(0),
+				}},
+			},
+			expectedPaths: []string{
+				"spec.broker.autoscaling.maxReplicas",
+				"spec.broker.autoscaling.targetCPUUtilizationPercentage",
+			},
+		},
+		"valid ingress exposure": {
+			spec: MemoryBrokerSpec{
+				Broker: CommonBrokerSpec{
+					Exposure: &BrokerExposure{
+						Ingress: &BrokerIngress{
+							Host:        "broker.example.com",
+							Annotations: map[string]string{"cert-manager.io/cluster-issuer": "letsencrypt"},
+						},
+					},
+				},
+			},
+		},
+		"invalid exposure": {
+			spec: MemoryBrokerSpec{
+				Broker: CommonBrokerSpec{
+					Exposure: &BrokerExposure{
+						ServiceType: corev1.ServiceTypeExternalName,
+						Ingress:     &BrokerIngress{Host: "Not_A_Host"},
+						HTTPRoute: &BrokerHTTPRoute{
+							Hostname:   "broker.example.com",
+							ParentRefs: []GatewayReference{{}},
+						},
+					},
+				},
+			},
+			expectedPaths: []string{
+				"spec.broker.exposure.httpRoute",
+				"spec.broker.exposure.httpRoute.parentRefs[0].name",
+				"spec.broker.exposure.ingress",
+				"spec.broker.exposure.ingress.host",
+				"spec.broker.exposure.serviceType",
+			},
+		},
+		"httpRoute without parent references": {
+			spec: MemoryBrokerSpec{
+				Broker: CommonBrokerSpec{
+					Exposure: &BrokerExposure{
+						HTTPRoute: &BrokerHTTPRoute{Hostname: "broker.example.com"},
+					},
+				},
+			},
+			expectedPaths: []string{"spec.broker.exposure.httpRoute.parentRefs"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mb := &MemoryBroker{Spec: tc.spec}
+			mb.SetDefaults(context.Background())
+			assertFieldErrorPaths(t, tc.expectedPaths, mb.Validate(context.Background()))
+		})
+	}
+}
+
+func TestKafkaBrokerValidation(t *testing.T) {
+	gssapi := func() *KafkaGSSAPI {
+		return &KafkaGSSAPI{
+			ServiceName:    "kafka",
+			Realm:          "EXAMPLE.COM",
+			Principal:      "broker",
+			KeyTab:         *secretValue("kerberos", "keytab"),
+			KerberosConfig: *secretValue("kerberos", "krb5.conf"),
+		}
+	}
+
+	testCases := map[string]struct {
+		spec          KafkaBrokerSpec
+		expectedPaths []string
+	}{
+		"bootstrap servers": {
+			spec: KafkaBrokerSpec{
+				Kafka: Kafka{BootstrapServers: []string{"kafka-0:9092", "kafka-1:9092"}},
+			},
+		},
+		"missing bootstrap servers": {
+			spec:          KafkaBrokerSpec{},
+			expectedPaths: []string{"spec.kafka.bootstrapServers"},
+		},
+		"empty bootstrap server": {
+			spec: KafkaBrokerSpec{
+				Kafka: Kafka{BootstrapServers: []string{"kafka-0:9092", ""}},
+			},
+			expectedPaths: []string{"spec.kafka.bootstrapServers[1]"},
+		},
+		"empty topic": {
+			spec: KafkaBrokerSpec{
+				Kafka: Kafka{
+					BootstrapServers: []string{"kafka-0:9092"},
+					Topic:            ptr.String(""),
+				},
+			},
+			expectedPaths: []string{"spec.kafka.topic"},
+		},
+		"gssapi authentication": {
+			spec: KafkaBrokerSpec{
+				Kafka: Kafka{
+					BootstrapServers: []string{"kafka-0:9092"},
+					SASL:             &KafkaSASL{GSSAPI: gssapi()},
+				},
+			},
+		},
+		"sasl without mechanism": {
+			spec: KafkaBrokerSpec{
+				Kafka: Kafka{
+					BootstrapServers: []string{"kafka-0:9092"},
+					SASL:             &KafkaSASL{},
+				},
+			},
+			expectedPaths: []string{"spec.kafka.sasl.gssapi"},
+		},
+		"incomplete gssapi": {
+			spec: KafkaBrokerSpec{
+				Kafka: Kafka{
+					BootstrapServers: []string{"kafka-0:9092"},
+					SASL: &KafkaSASL{GSSAPI: &KafkaGSSAPI{
+						ServiceName:    "kafka",
+						KeyTab:         *secretValue("kerberos", "keytab"),
+						KerberosConfig: *secretValue("kerberos", ""),
+					}},
+				},
+			},
+			expectedPaths: []string{
+				"spec.kafka.sasl.gssapi.realm",
+				"spec.kafka.sasl.gssapi.principal",
+				"spec.kafka.sasl.gssapi.kerberosConfig.secretKeyRef.key",
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			kb := &KafkaBroker{Spec: tc.spec}
+			kb.SetDefaults(context.Background())
+			assertFieldErrorPaths(t, tc.expectedPaths, kb.Validate(context.Background()))
+		})
+	}
+}
+
+func TestBrokerValidation(t *testing.T) {
+	testCases := map[string]struct {
+		spec          BrokerSpec
+		expectedPaths []string
+	}{
+		"default class": {
+			spec: BrokerSpec{},
+		},
+		"redis class with parameters": {
+			spec: BrokerSpec{
+				Class: BrokerClassRedis,
+				Redis: &Redis{StreamMaxLen: intPtr(1000)},
+			},
+		},
+		"unknown class": {
+			spec: BrokerSpec{
+				Class: "ChannelBasedBroker",
+			},
+			expectedPaths: []string{"spec.class"},
+		},
+		"kafka class without parameters": {
+			spec: BrokerSpec{
+				Class: BrokerClassKafka,
+			},
+			expectedPaths: []string{"spec.kafka"},
+		},
+		"parameters for other class": {
+			spec: BrokerSpec{
+				Class: BrokerClassMemory,
+				Redis: &Redis{},
+			},
+			expectedPaths: []string{"spec.redis"},
+		},
+		"invalid class parameters": {
+			spec: BrokerSpec{
+				Class:  BrokerClassMemory,
+				Memory: &Memory{BufferSize: intPtr(0)},
+				Broker: CommonBrokerSpec{Port: intPtr(0)},
+			},
+			expectedPaths: []string{"spec.memory.bufferSize", "spec.broker.port"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			b := &Broker{Spec: tc.spec}
+			b.SetDefaults(context.Background())
+			assertFieldErrorPaths(t, tc.expectedPaths, b.Validate(context.Background()))
+		})
+	}
+}
+
+func assertFieldErrorPaths(t *testing.T, expected []string, errs *apis.FieldError) {
+	if len(expected) == 0 {
+		assert.Nil(t, errs)
+		return
+	}
+
+	if assert.NotNil(t, errs) {
+		var paths []string
+		for _, e := range errs.WrappedErrors() {
+			paths = append(paths, e.Paths...)
+		}
+		assert.ElementsMatch(t, expected, paths)
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
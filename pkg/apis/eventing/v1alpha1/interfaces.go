@@ -7,13 +7,173 @@ import (
 	"context"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/kmeta"
 )
 
-type Broker struct {
+// CommonBrokerSpec contains the parameters shared by all broker kinds.
+type CommonBrokerSpec struct {
 	Port *int `json:"port,omitempty"`
 
 	Observability *Observability `json:"observability,omitempty"`
+
+	// Delivery contains the default delivery spec for Triggers that
+	// reference this broker. Triggers can override each of its fields.
+	// +optional
+	Delivery *eventingduckv1.DeliverySpec `json:"delivery,omitempty"`
+
+	// Replicas is the fixed number of broker instances. It cannot be
+	// combined with Autoscaling. Defaults to 1.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Autoscaling lets a HorizontalPodAutoscaler manage the number of
+	// broker instances.
+	// +optional
+	Autoscaling *Autoscaling `json:"autoscaling,omitempty"`
+
+	// PodTemplate customizes the broker pods.
+	// +optional
+	PodTemplate *PodTemplate `json:"podTemplate,omitempty"`
+
+	// TriggerNamespaceSelector selects the namespaces, other than the
+	// broker's, whose Triggers are allowed to subscribe to this broker.
+	// When not informed only Triggers at the broker's namespace are allowed.
+	// An empty selector allows all namespaces.
+	// +optional
+	TriggerNamespaceSelector *metav1.LabelSelector `json:"triggerNamespaceSelector,omitempty"`
+
+	// Exposure makes the broker ingestion reachable from outside the
+	// cluster.
+	// +optional
+	Exposure *BrokerExposure `json:"exposure,omitempty"`
+}
+
+// BrokerExposure configures how the broker ingestion is reachable from outside
+// the cluster. The broker Service type can be combined with either an Ingress
+// or an HTTPRoute.
+type BrokerExposure struct {
+	// ServiceType for the broker Service: ClusterIP, NodePort or
+	// LoadBalancer. Defaults to ClusterIP.
+	// +optional
+	ServiceType corev1.ServiceType `json:"serviceType,omitempty"`
+
+	// Ingress routes a host name to the broker Service.
+	// +optional
+	Ingress *BrokerIngress `json:"ingress,omitempty"`
+
+	// HTTPRoute attaches a Gateway API route for a host name to the broker
+	// Service.
+	// +optional
+	HTTPRoute *BrokerHTTPRoute `json:"httpRoute,omitempty"`
+}
+
+// BrokerIngress contains the parameters of the Ingress created for a broker.
+type BrokerIngress struct {
+	// Host name routed to the broker.
+	Host string `json:"host"`
+
+	// ClassName of the Ingress controller. Defaults to the cluster default
+	// class.
+	// +optional
+	ClassName *string `json:"className,omitempty"`
+
+	// Annotations added to the Ingress, usually to configure the Ingress
+	// controller.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// TLSSecret is the name of a Secret at the broker's namespace containing
+	// the certificate for the host name. When informed the Ingress
+	// terminates TLS and the public URL uses the https scheme.
+	// +optional
+	TLSSecret *string `json:"tlsSecret,omitempty"`
+}
+
+// BrokerHTTPRoute contains the parameters of the Gateway API HTTPRoute created
+// for a broker.
+type BrokerHTTPRoute struct {
+	// Hostname routed to the broker.
+	Hostname string `json:"hostname"`
+
+	// ParentRefs are the Gateways the route attaches to.
+	ParentRefs []GatewayReference `json:"parentRefs"`
+
+	// HTTPS indicates that the Gateway listeners terminate TLS, which makes
+	// the public URL use the https scheme.
+	// +optional
+	HTTPS bool `json:"https,omitempty"`
+}
+
+// GatewayReference identifies a Gateway, and optionally one of its listeners.
+type GatewayReference struct {
+	// Name of the Gateway.
+	Name string `json:"name"`
+
+	// Namespace of the Gateway. Defaults to the broker's namespace.
+	// +optional
+	Namespace *string `json:"namespace,omitempty"`
+
+	// SectionName is the name of the Gateway listener.
+	// +optional
+	SectionName *string `json:"sectionName,omitempty"`
+}
+
+// PodTemplate contains the user customizable parameters of the pods
+// created for a broker.
+type PodTemplate struct {
+	// Labels added to the pods. Labels managed by the controller take precedence.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations added to the pods.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Resources for the main container.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// NodeSelector for the pods.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations for the pods.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Affinity scheduling rules for the pods.
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// PriorityClassName for the pods.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+type Autoscaling struct {
+	// MinReplicas is the lower limit for the number of broker instances.
+	// Defaults to 1.
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the upper limit for the number of broker instances.
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// TargetCPUUtilizationPercentage is the average CPU utilization
+	// across broker instances that the autoscaler aims for.
+	// +optional
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+
+	// Metrics contains custom metric specifications, added to the CPU
+	// target when informed.
+	// +optional
+	Metrics []autoscalingv2.MetricSpec `json:"metrics,omitempty"`
 }
 
 type Observability struct {
@@ -25,10 +185,22 @@ type ReconcilableBroker interface {
 
 	GetReconcilableBrokerStatus() ReconcilableBrokerStatus
 	GetOwnedObjectsSuffix() string
-	GetReconcilableBrokerSpec() *Broker
+	GetReconcilableBrokerSpec() *CommonBrokerSpec
+	IsReady() bool
+}
+
+// TriggerConsumerStatusProvider is implemented by brokers that report the
+// events waiting to be delivered to each Trigger.
+type TriggerConsumerStatusProvider interface {
+	// GetTriggerConsumerStatus returns the consumer status for the Trigger
+	// configuration key, or nil if unknown.
+	GetTriggerConsumerStatus(key string) *TriggerConsumerStatus
 }
 
 type ReconcilableBrokerStatus interface {
+	// Broker top level readiness.
+	GetTopLevelCondition() *apis.Condition
+
 	// Secret as config status management.
 	MarkConfigSecretFailed(reason, messageFormat string, messageA ...interface{})
 	MarkConfigSecretReady()
@@ -53,6 +225,29 @@ type ReconcilableBrokerStatus interface {
 	MarkBrokerServiceFailed(reason, messageFormat string, messageA ...interface{})
 	MarkBrokerServiceReady()
 
+	// Broker addresses.
+	GetAddress() *apis.URL
+	GetAddresses() []duckv1.Addressable
+
+	// Broker level dead letter sink management.
+	GetDeadLetterSinkURI() *apis.URL
+	MarkDeadLetterSinkResolvedSucceeded(uri *apis.URL)
+	MarkDeadLetterSinkNotConfigured()
+	MarkDeadLetterSinkResolvedFailed(reason, messageFormat string, messageA ...interface{})
+
+	// User referenced Secrets and ConfigMaps management.
+	MarkReferencesResolved()
+	MarkReferencesResolvedFailed(reason, messageFormat string, messageA ...interface{})
+
+	// Broker external exposure management.
+	GetPublicURL() *apis.URL
+	MarkExposureReady(publicURL *apis.URL)
+	MarkExposureNotConfigured()
+	MarkExposureUnknown(reason, messageFormat string, messageA ...interface{})
+	MarkExposureFailed(reason, messageFormat string, messageA ...interface{})
+	IsHTTPRouteCreated() bool
+	SetHTTPRouteCreated(created bool)
+
 	// Broker Endpoints status management.
 	MarkBrokerEndpointsTrue()
 	MarkBrokerEndpointsUnknown(reason, messageFormat string, messageA ...interface{})
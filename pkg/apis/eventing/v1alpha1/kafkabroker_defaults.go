@@ -0,0 +1,15 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+
+	"knative.dev/pkg/apis"
+)
+
+// SetDefaults sets default values for the KafkaBroker.
+func (kb *KafkaBroker) SetDefaults(ctx context.Context) {
+	kb.Spec.Broker.SetDefaults(apis.WithinParent(ctx, kb.ObjectMeta))
+}
@@ -0,0 +1,306 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"sync"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// KafkaBrokerBroker refers to the TriggerMesh Broker that manages events on top of Kafka.
+
+const (
+	KafkaBrokerConditionReady                                          = apis.ConditionReady
+	KafkaBrokerBrokerDeployment                     apis.ConditionType = "BrokerDeploymentReady"
+	KafkaBrokerBrokerServiceAccount                 apis.ConditionType = "BrokerServiceAccountReady"
+	KafkaBrokerBrokerRoleBinding                    apis.ConditionType = "KafkaBrokerBrokerRoleBinding"
+	KafkaBrokerBrokerService                        apis.ConditionType = "BrokerServiceReady"
+	KafkaBrokerBrokerServiceEndpointsConditionReady apis.ConditionType = "BrokerEndpointsReady"
+	KafkaBrokerConfigSecret                         apis.ConditionType = "BrokerConfigSecretReady"
+	KafkaBrokerConditionAddressable                 apis.ConditionType = "Addressable"
+	KafkaBrokerStatusConfig                         apis.ConditionType = "BrokerStatusConfigReady"
+	KafkaBrokerDeadLetterSinkResolved               apis.ConditionType = "DeadLetterSinkResolved"
+	KafkaBrokerReferencesResolved                   apis.ConditionType = "ReferencesResolved"
+	KafkaBrokerExposure                             apis.ConditionType = "ExposureReady"
+)
+
+var kafkaBrokerCondSet = apis.NewLivingConditionSet(
+	KafkaBrokerBrokerServiceAccount,
+	KafkaBrokerBrokerRoleBinding,
+	KafkaBrokerBrokerDeployment,
+	KafkaBrokerBrokerService,
+	KafkaBrokerBrokerServiceEndpointsConditionReady,
+	KafkaBrokerConfigSecret,
+	KafkaBrokerConditionAddressable,
+	KafkaBrokerStatusConfig,
+	KafkaBrokerDeadLetterSinkResolved,
+	KafkaBrokerReferencesResolved,
+	KafkaBrokerExposure,
+)
+var kafkaBrokerCondSetLock = sync.RWMutex{}
+
+// GetGroupVersionKind returns GroupVersionKind for Brokers
+func (t *KafkaBroker) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("KafkaBroker")
+}
+
+// GetStatus retrieves the status of the Broker. Implements the KRShaped interface.
+func (t *KafkaBroker) GetStatus() *duckv1.Status {
+	return &t.Status.Status
+}
+
+// GetReconcilableBrokerSpec returns the all brokers common Broker spec.
+func (t *KafkaBroker) GetReconcilableBrokerSpec() *CommonBrokerSpec {
+	return &t.Spec.Broker
+}
+
+// GetReconcilableBrokerStatus returns a status interface that allows generic reconciler
+// to manage it.
+func (t *KafkaBroker) GetReconcilableBrokerStatus() ReconcilableBrokerStatus {
+	return &t.Status
+}
+
+// GetOwnedObjectsSuffix returns a string to be appended for created/owned objects.
+func (t *KafkaBroker) GetOwnedObjectsSuffix() string {
+	return "kb"
+}
+
+// GetConditionSet retrieves the condition set for this resource. Implements the KRShaped interface.
+func (b *KafkaBroker) GetConditionSet() apis.ConditionSet {
+	kafkaBrokerCondSetLock.RLock()
+	defer kafkaBrokerCondSetLock.RUnlock()
+
+	return kafkaBrokerCondSet
+}
+
+// GetConditionSet retrieves the condition set for this resource.
+func (bs *KafkaBrokerStatus) GetConditionSet() apis.ConditionSet {
+	kafkaBrokerCondSetLock.RLock()
+	defer kafkaBrokerCondSetLock.RUnlock()
+
+	return kafkaBrokerCondSet
+}
+
+// GetTopLevelCondition returns the top level Condition.
+func (bs *KafkaBrokerStatus) GetTopLevelCondition() *apis.Condition {
+	return bs.GetConditionSet().Manage(bs).GetTopLevelCondition()
+}
+
+// SetAddress makes this Broker addressable by setting the URI. It also
+// sets the BrokerConditionAddressable to true.
+func (bs *KafkaBrokerStatus) SetAddress(url *apis.URL) {
+	bs.Address.URL = url
+	if url != nil {
+		bs.GetConditionSet().Manage(bs).MarkTrue(KafkaBrokerConditionAddressable)
+	} else {
+		bs.GetConditionSet().Manage(bs).MarkFalse(KafkaBrokerConditionAddressable, "nil URL", "URL is nil")
+	}
+}
+
+// GetAddress returns the URI where the broker is reachable.
+func (bs *KafkaBrokerStatus) GetAddress() *apis.URL {
+	return bs.Address.URL
+}
+
+// GetAddresses returns the addresses the broker can be reached at.
+func (bs *KafkaBrokerStatus) GetAddresses() []duckv1.Addressable {
+	return bs.Addresses
+}
+
+// GetCondition returns the condition currently associated with the given type, or nil.
+func (bs *KafkaBrokerStatus) GetCondition(t apis.ConditionType) *apis.Condition {
+	return bs.GetConditionSet().Manage(bs).GetCondition(t)
+}
+
+// IsReady returns true if the resource is ready overall and the latest spec has been observed.
+func (b *KafkaBroker) IsReady() bool {
+	bs := b.Status
+	return bs.ObservedGeneration == b.Generation &&
+		b.GetConditionSet().Manage(&bs).IsHappy()
+}
+
+// InitializeConditions sets relevant unset conditions to Unknown state.
+func (bs *KafkaBrokerStatus) InitializeConditions() {
+	bs.GetConditionSet().Manage(bs).InitializeConditions()
+}
+
+func (bs *KafkaBrokerStatus) MarkConfigSecretFailed(reason, messageFormat string, messageA ...interface{}) {
+	kafkaBrokerCondSet.Manage(bs).MarkFalse(KafkaBrokerConfigSecret, reason, messageFormat, messageA...)
+}
+
+func (bs *KafkaBrokerStatus) MarkConfigSecretUnknown(reason, messageFormat string, messageA ...interface{}) {
+	kafkaBrokerCondSet.Manage(bs).MarkUnknown(KafkaBrokerConfigSecret, reason, messageFormat, messageA...)
+}
+
+func (bs *KafkaBrokerStatus) MarkConfigSecretReady() {
+	kafkaBrokerCondSet.Manage(bs).MarkTrue(KafkaBrokerConfigSecret)
+}
+
+func (bs *KafkaBrokerStatus) MarkStatusConfigFailed(reason, messageFormat string, messageA ...interface{}) {
+	kafkaBrokerCondSet.Manage(bs).MarkFalse(KafkaBrokerStatusConfig, reason, messageFormat, messageA...)
+}
+
+func (bs *KafkaBrokerStatus) MarkStatusConfigUnknown(reason, messageFormat string, messageA ...interface{}) {
+	kafkaBrokerCondSet.Manage(bs).MarkUnknown(KafkaBrokerStatusConfig, reason, messageFormat, messageA...)
+}
+
+func (bs *KafkaBrokerStatus) MarkStatusConfigReady() {
+	kafkaBrokerCondSet.Manage(bs).MarkTrue(KafkaBrokerStatusConfig)
+}
+
+// Manage Kafka broker service account and role binding.
+
+func (bs *KafkaBrokerStatus) MarkBrokerServiceAccountFailed(reason, messageFormat string, messageA ...interface{}) {
+	kafkaBrokerCondSet.Manage(bs).MarkFalse(KafkaBrokerBrokerServiceAccount, reason, messageFormat, messageA...)
+}
+
+func (bs *KafkaBrokerStatus) MarkBrokerServiceAccountUnknown(reason, messageFormat string, messageA ...interface{}) {
+	kafkaBrokerCondSet.Manage(bs).MarkUnknown(KafkaBrokerBrokerServiceAccount, reason, messageFormat, messageA...)
+}
+
+func (bs *KafkaBrokerStatus) MarkBrokerServiceAccountReady() {
+	kafkaBrokerCondSet.Manage(bs).MarkTrue(KafkaBrokerBrokerServiceAccount)
+}
+
+func (bs *KafkaBrokerStatus) MarkBrokerRoleBindingFailed(reason, messageFormat string, messageA ...interface{}) {
+	kafkaBrokerCondSet.Manage(bs).MarkFalse(KafkaBrokerBrokerRoleBinding, reason, messageFormat, messageA...)
+}
+
+func (bs *KafkaBrokerStatus) MarkBrokerRoleBindingUnknown(reason, messageFormat string, messageA ...interface{}) {
+	kafkaBrokerCondSet.Manage(bs).MarkUnknown(KafkaBrokerBrokerRoleBinding, reason, messageFormat, messageA...)
+}
+
+func (bs *KafkaBrokerStatus) MarkBrokerRoleBindingReady() {
+	kafkaBrokerCondSet.Manage(bs).MarkTrue(KafkaBrokerBrokerRoleBinding)
+}
+
+// Manage Kafka broker state for
+// Deployment, Service and Endpoint
+
+func (bs *KafkaBrokerStatus) MarkBrokerDeploymentFailed(reason, messageFormat string, messageA ...interface{}) {
+	kafkaBrokerCondSet.Manage(bs).MarkFalse(KafkaBrokerBrokerDeployment, reason, messageFormat, messageA...)
+}
+
+func (bs *KafkaBrokerStatus) MarkBrokerDeploymentUnknown(reason, messageFormat string, messageA ...interface{}) {
+	kafkaBrokerCondSet.Manage(bs).MarkUnknown(KafkaBrokerBrokerDeployment, reason, messageFormat, messageA...)
+}
+
+func (bs *KafkaBrokerStatus) PropagateBrokerDeploymentAvailability(ctx context.Context, ds *appsv1.DeploymentStatus) {
+	for _, cond := range ds.Conditions {
+
+		if cond.Type == appsv1.DeploymentAvailable {
+			switch cond.Status {
+			case corev1.ConditionTrue:
+				kafkaBrokerCondSet.Manage(bs).MarkTrue(KafkaBrokerBrokerDeployment)
+			case corev1.ConditionFalse:
+				bs.MarkBrokerDeploymentFailed("BrokerDeploymentFalse", "The status of Broker Deployment is False: %s : %s", cond.Reason, cond.Message)
+			default:
+				// expected corev1.ConditionUnknown
+				bs.MarkBrokerDeploymentUnknown("BrokerDeploymentUnknown", "The status of Broker Deployment is Unknown: %s : %s", cond.Reason, cond.Message)
+			}
+		}
+	}
+}
+
+func (bs *KafkaBrokerStatus) MarkBrokerServiceFailed(reason, messageFormat string, messageA ...interface{}) {
+	kafkaBrokerCondSet.Manage(bs).MarkFalse(KafkaBrokerBrokerService, reason, messageFormat, messageA...)
+}
+
+func (bs *KafkaBrokerStatus) MarkBrokerServiceUnknown(reason, messageFormat string, messageA ...interface{}) {
+	kafkaBrokerCondSet.Manage(bs).MarkUnknown(KafkaBrokerBrokerService, reason, messageFormat, messageA...)
+}
+
+func (bs *KafkaBrokerStatus) MarkBrokerServiceReady() {
+	kafkaBrokerCondSet.Manage(bs).MarkTrue(KafkaBrokerBrokerService)
+}
+
+func (bs *KafkaBrokerStatus) MarkBrokerEndpointsFailed(reason, messageFormat string, messageA ...interface{}) {
+	kafkaBrokerCondSet.Manage(bs).MarkFalse(KafkaBrokerBrokerServiceEndpointsConditionReady, reason, messageFormat, messageA...)
+}
+
+func (bs *KafkaBrokerStatus) MarkBrokerEndpointsUnknown(reason, messageFormat string, messageA ...interface{}) {
+	kafkaBrokerCondSet.Manage(bs).MarkUnknown(KafkaBrokerBrokerServiceEndpointsConditionReady, reason, messageFormat, messageA...)
+}
+
+func (bs *KafkaBrokerStatus) MarkBrokerEndpointsTrue() {
+	kafkaBrokerCondSet.Manage(bs).MarkTrue(KafkaBrokerBrokerServiceEndpointsConditionReady)
+}
+
+// Manage broker level dead letter sink.
+
+// GetDeadLetterSinkURI returns the resolved broker level dead letter sink.
+func (bs *KafkaBrokerStatus) GetDeadLetterSinkURI() *apis.URL {
+	return bs.DeadLetterSinkURI
+}
+
+func (bs *KafkaBrokerStatus) MarkDeadLetterSinkResolvedSucceeded(uri *apis.URL) {
+	bs.DeadLetterSinkURI = uri
+	kafkaBrokerCondSet.Manage(bs).MarkTrue(KafkaBrokerDeadLetterSinkResolved)
+}
+
+func (bs *KafkaBrokerStatus) MarkDeadLetterSinkNotConfigured() {
+	bs.DeadLetterSinkURI = nil
+	kafkaBrokerCondSet.Manage(bs).MarkTrueWithReason(KafkaBrokerDeadLetterSinkResolved,
+		"DeadLetterSinkNotConfigured", "No dead letter sink is configured.")
+}
+
+func (bs *KafkaBrokerStatus) MarkDeadLetterSinkResolvedFailed(reason, messageFormat string, messageA ...interface{}) {
+	bs.DeadLetterSinkURI = nil
+	kafkaBrokerCondSet.Manage(bs).MarkFalse(KafkaBrokerDeadLetterSinkResolved, reason, messageFormat, messageA...)
+}
+
+// Manage user referenced Secrets and ConfigMaps.
+
+func (bs *KafkaBrokerStatus) MarkReferencesResolved() {
+	kafkaBrokerCondSet.Manage(bs).MarkTrue(KafkaBrokerReferencesResolved)
+}
+
+func (bs *KafkaBrokerStatus) MarkReferencesResolvedFailed(reason, messageFormat string, messageA ...interface{}) {
+	kafkaBrokerCondSet.Manage(bs).MarkFalse(KafkaBrokerReferencesResolved, reason, messageFormat, messageA...)
+}
+
+// Manage broker external exposure.
+
+// GetPublicURL returns the URL where the broker is reachable from outside the
+// cluster.
+func (bs *KafkaBrokerStatus) GetPublicURL() *apis.URL {
+	return bs.PublicURL
+}
+
+func (bs *KafkaBrokerStatus) MarkExposureReady(publicURL *apis.URL) {
+	bs.PublicURL = publicURL
+	kafkaBrokerCondSet.Manage(bs).MarkTrue(KafkaBrokerExposure)
+}
+
+func (bs *KafkaBrokerStatus) MarkExposureNotConfigured() {
+	bs.PublicURL = nil
+	kafkaBrokerCondSet.Manage(bs).MarkTrueWithReason(KafkaBrokerExposure,
+		"ExposureNotConfigured", "The broker is not exposed outside the cluster.")
+}
+
+func (bs *KafkaBrokerStatus) MarkExposureUnknown(reason, messageFormat string, messageA ...interface{}) {
+	bs.PublicURL = nil
+	kafkaBrokerCondSet.Manage(bs).MarkUnknown(KafkaBrokerExposure, reason, messageFormat, messageA...)
+}
+
+func (bs *KafkaBrokerStatus) MarkExposureFailed(reason, messageFormat string, messageA ...interface{}) {
+	bs.PublicURL = nil
+	kafkaBrokerCondSet.Manage(bs).MarkFalse(KafkaBrokerExposure, reason, messageFormat, messageA...)
+}
+
+// IsHTTPRouteCreated returns whether the controller created an HTTPRoute for
+// the broker.
+func (bs *KafkaBrokerStatus) IsHTTPRouteCreated() bool {
+	return bs.HTTPRouteCreated
+}
+
+func (bs *KafkaBrokerStatus) SetHTTPRouteCreated(created bool) {
+	bs.HTTPRouteCreated = created
+}
@@ -0,0 +1,139 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// +genclient
+// +genreconciler
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// KafkaBroker is a Kafka based broker implementation that collects a pool of
+// events that are consumable using Triggers. Brokers provide a well-known endpoint
+// for event delivery that senders can use with minimal knowledge of the event
+// routing strategy. Subscribers use Triggers to request delivery of events from a
+// broker's pool to a specific URL or Addressable endpoint.
+type KafkaBroker struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the desired state of the broker.
+	Spec KafkaBrokerSpec `json:"spec,omitempty"`
+
+	// Status represents the current state of the broker. This data may be out of
+	// date.
+	// +optional
+	Status KafkaBrokerStatus `json:"status,omitempty"`
+}
+
+var (
+	// Make sure this is a kubernetes object.
+	_ runtime.Object = (*KafkaBroker)(nil)
+	// Check that we can reconcile this object as a Broker.
+	_ ReconcilableBroker = (*KafkaBroker)(nil)
+	// Check that the type conforms to the duck Knative Resource shape.
+	_ duckv1.KRShaped = (*KafkaBroker)(nil)
+	// Check that the type can be validated and defaulted.
+	_ apis.Validatable = (*KafkaBroker)(nil)
+	_ apis.Defaultable = (*KafkaBroker)(nil)
+)
+
+type Kafka struct {
+	// Kafka bootstrap servers addresses.
+	BootstrapServers []string `json:"bootstrapServers"`
+
+	// Topic name used by the broker. It is created by the broker if it does not
+	// exist, using the Kafka cluster defaults for partitions and replication.
+	// +optional
+	Topic *string `json:"topic,omitempty"`
+
+	// Whether the Kafka offset for the event is added as a CloudEvents attribute.
+	// +optional
+	EnableTrackingID *bool `json:"enableTrackingID,omitempty"`
+
+	// SASL authentication to Kafka.
+	// +optional
+	SASL *KafkaSASL `json:"sasl,omitempty"`
+}
+
+// KafkaSASL contains the SASL mechanisms supported by the broker.
+type KafkaSASL struct {
+	// GSSAPI authenticates using Kerberos.
+	GSSAPI *KafkaGSSAPI `json:"gssapi,omitempty"`
+}
+
+// KafkaGSSAPI contains the Kerberos authentication parameters.
+type KafkaGSSAPI struct {
+	// ServiceName of the Kafka brokers at Kerberos.
+	ServiceName string `json:"serviceName"`
+
+	// Realm of the principal.
+	Realm string `json:"realm"`
+
+	// Principal used to authenticate.
+	Principal string `json:"principal"`
+
+	// KeyTab for the principal.
+	KeyTab SecretValueFromSource `json:"keyTab"`
+
+	// KerberosConfig contains the krb5.conf file contents.
+	KerberosConfig SecretValueFromSource `json:"kerberosConfig"`
+}
+
+type KafkaBrokerSpec struct {
+	Kafka Kafka `json:"kafka"`
+
+	Broker CommonBrokerSpec `json:"broker,omitempty"`
+}
+
+// KafkaBrokerStatus represents the current state of a Kafka broker.
+type KafkaBrokerStatus struct {
+	// inherits duck/v1 Status, which currently provides:
+	// * ObservedGeneration - the 'Generation' of the Broker that was last processed by the controller.
+	// * Conditions - the latest available observations of a resource's current state.
+	duckv1.Status `json:",inline"`
+
+	// Broker is Addressable. It exposes the endpoint as an URI to get events
+	// delivered into the Broker mesh.
+	// +optional
+	Address duckv1.Addressable `json:"address,omitempty"`
+
+	// Addresses lists the addresses the broker can be reached at.
+	// +optional
+	Addresses []duckv1.Addressable `json:"addresses,omitempty"`
+
+	// PublicURL is the address where the broker is reachable from outside
+	// the cluster.
+	// +optional
+	PublicURL *apis.URL `json:"publicURL,omitempty"`
+
+	// HTTPRouteCreated is set when the controller created a Gateway API
+	// HTTPRoute for the broker that needs to be removed when no longer
+	// configured.
+	// +optional
+	HTTPRouteCreated bool `json:"httpRouteCreated,omitempty"`
+
+	// DeliveryStatus contains the resolved URL to the broker level dead
+	// letter sink.
+	// +optional
+	eventingduckv1.DeliveryStatus `json:",inline"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// KafkaBrokerList is a collection of Brokers.
+type KafkaBrokerList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []KafkaBroker `json:"items"`
+}
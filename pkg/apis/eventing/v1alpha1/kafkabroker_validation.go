@@ -0,0 +1,74 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+
+	"knative.dev/pkg/apis"
+)
+
+// Validate the KafkaBroker.
+func (kb *KafkaBroker) Validate(ctx context.Context) *apis.FieldError {
+	ctx = apis.WithinParent(ctx, kb.ObjectMeta)
+	return kb.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec")
+}
+
+// Validate the KafkaBrokerSpec.
+func (kbs *KafkaBrokerSpec) Validate(ctx context.Context) *apis.FieldError {
+	return kbs.Broker.Validate(ctx).ViaField("broker").
+		Also(kbs.Kafka.Validate(ctx).ViaField("kafka"))
+}
+
+// Validate the Kafka parameters.
+func (k *Kafka) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	if len(k.BootstrapServers) == 0 {
+		errs = errs.Also(apis.ErrMissingField("bootstrapServers"))
+	}
+	for i, s := range k.BootstrapServers {
+		if s == "" {
+			errs = errs.Also(apis.ErrInvalidArrayValue(s, "bootstrapServers", i))
+		}
+	}
+
+	if k.Topic != nil && *k.Topic == "" {
+		errs = errs.Also(apis.ErrInvalidValue(*k.Topic, "topic",
+			"must not be empty when informed"))
+	}
+
+	if k.SASL != nil {
+		errs = errs.Also(k.SASL.Validate(ctx).ViaField("sasl"))
+	}
+
+	return errs
+}
+
+// Validate the Kafka SASL parameters.
+func (ks *KafkaSASL) Validate(ctx context.Context) *apis.FieldError {
+	if ks.GSSAPI == nil {
+		return apis.ErrMissingOneOf("gssapi")
+	}
+
+	return ks.GSSAPI.Validate(ctx).ViaField("gssapi")
+}
+
+// Validate the Kafka GSSAPI parameters.
+func (kg *KafkaGSSAPI) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	if kg.ServiceName == "" {
+		errs = errs.Also(apis.ErrMissingField("serviceName"))
+	}
+	if kg.Realm == "" {
+		errs = errs.Also(apis.ErrMissingField("realm"))
+	}
+	if kg.Principal == "" {
+		errs = errs.Also(apis.ErrMissingField("principal"))
+	}
+
+	return errs.Also(kg.KeyTab.Validate(ctx).ViaField("keyTab")).
+		Also(kg.KerberosConfig.Validate(ctx).ViaField("kerberosConfig"))
+}
@@ -27,6 +27,9 @@ const (
 	MemoryBrokerConfigSecret                         apis.ConditionType = "BrokerConfigSecretReady"
 	MemoryBrokerConditionAddressable                 apis.ConditionType = "Addressable"
 	MemoryBrokerStatusConfig                         apis.ConditionType = "BrokerStatusConfigReady"
+	MemoryBrokerDeadLetterSinkResolved               apis.ConditionType = "DeadLetterSinkResolved"
+	MemoryBrokerReferencesResolved                   apis.ConditionType = "ReferencesResolved"
+	MemoryBrokerExposure                             apis.ConditionType = "ExposureReady"
 )
 
 var memoryBrokerCondSet = apis.NewLivingConditionSet(
@@ -38,6 +41,9 @@ var memoryBrokerCondSet = apis.NewLivingConditionSet(
 	MemoryBrokerConfigSecret,
 	MemoryBrokerConditionAddressable,
 	MemoryBrokerStatusConfig,
+	MemoryBrokerDeadLetterSinkResolved,
+	MemoryBrokerReferencesResolved,
+	MemoryBrokerExposure,
 )
 var memoryBrokerCondSetLock = sync.RWMutex{}
 
@@ -52,7 +58,7 @@ func (t *MemoryBroker) GetStatus() *duckv1.Status {
 }
 
 // GetReconcilableBrokerSpec returns the all brokers common Broker spec.
-func (t *MemoryBroker) GetReconcilableBrokerSpec() *Broker {
+func (t *MemoryBroker) GetReconcilableBrokerSpec() *CommonBrokerSpec {
 	return &t.Spec.Broker
 }
 
@@ -99,6 +105,16 @@ func (bs *MemoryBrokerStatus) SetAddress(url *apis.URL) {
 	}
 }
 
+// GetAddress returns the URI where the broker is reachable.
+func (bs *MemoryBrokerStatus) GetAddress() *apis.URL {
+	return bs.Address.URL
+}
+
+// GetAddresses returns the addresses the broker can be reached at.
+func (bs *MemoryBrokerStatus) GetAddresses() []duckv1.Addressable {
+	return bs.Addresses
+}
+
 // GetCondition returns the condition currently associated with the given type, or nil.
 func (bs *MemoryBrokerStatus) GetCondition(t apis.ConditionType) *apis.Condition {
 	return bs.GetConditionSet().Manage(bs).GetCondition(t)
@@ -217,3 +233,75 @@ func (bs *MemoryBrokerStatus) MarkBrokerEndpointsUnknown(reason, messageFormat s
 func (bs *MemoryBrokerStatus) MarkBrokerEndpointsTrue() {
 	memoryBrokerCondSet.Manage(bs).MarkTrue(MemoryBrokerBrokerServiceEndpointsConditionReady)
 }
+
+// Manage broker level dead letter sink.
+
+// GetDeadLetterSinkURI returns the resolved broker level dead letter sink.
+func (bs *MemoryBrokerStatus) GetDeadLetterSinkURI() *apis.URL {
+	return bs.DeadLetterSinkURI
+}
+
+func (bs *MemoryBrokerStatus) MarkDeadLetterSinkResolvedSucceeded(uri *apis.URL) {
+	bs.DeadLetterSinkURI = uri
+	memoryBrokerCondSet.Manage(bs).MarkTrue(MemoryBrokerDeadLetterSinkResolved)
+}
+
+func (bs *MemoryBrokerStatus) MarkDeadLetterSinkNotConfigured() {
+	bs.DeadLetterSinkURI = nil
+	memoryBrokerCondSet.Manage(bs).MarkTrueWithReason(MemoryBrokerDeadLetterSinkResolved,
+		"DeadLetterSinkNotConfigured", "No dead letter sink is configured.")
+}
+
+func (bs *MemoryBrokerStatus) MarkDeadLetterSinkResolvedFailed(reason, messageFormat string, messageA ...interface{}) {
+	bs.DeadLetterSinkURI = nil
+	memoryBrokerCondSet.Manage(bs).MarkFalse(MemoryBrokerDeadLetterSinkResolved, reason, messageFormat, messageA...)
+}
+
+// Manage user referenced Secrets and ConfigMaps.
+
+func (bs *MemoryBrokerStatus) MarkReferencesResolved() {
+	memoryBrokerCondSet.Manage(bs).MarkTrue(MemoryBrokerReferencesResolved)
+}
+
+func (bs *MemoryBrokerStatus) MarkReferencesResolvedFailed(reason, messageFormat string, messageA ...interface{}) {
+	memoryBrokerCondSet.Manage(bs).MarkFalse(MemoryBrokerReferencesResolved, reason, messageFormat, messageA...)
+}
+
+// Manage broker external exposure.
+
+// GetPublicURL returns the URL where the broker is reachable from outside the
+// cluster.
+func (bs *MemoryBrokerStatus) GetPublicURL() *apis.URL {
+	return bs.PublicURL
+}
+
+func (bs *MemoryBrokerStatus) MarkExposureReady(publicURL *apis.URL) {
+	bs.PublicURL = publicURL
+	memoryBrokerCondSet.Manage(bs).MarkTrue(MemoryBrokerExposure)
+}
+
+func (bs *MemoryBrokerStatus) MarkExposureNotConfigured() {
+	bs.PublicURL = nil
+	memoryBrokerCondSet.Manage(bs).MarkTrueWithReason(MemoryBrokerExposure,
+		"ExposureNotConfigured", "The broker is not exposed outside the cluster.")
+}
+
+func (bs *MemoryBrokerStatus) MarkExposureUnknown(reason, messageFormat string, messageA ...interface{}) {
+	bs.PublicURL = nil
+	memoryBrokerCondSet.Manage(bs).MarkUnknown(MemoryBrokerExposure, reason, messageFormat, messageA...)
+}
+
+func (bs *MemoryBrokerStatus) MarkExposureFailed(reason, messageFormat string, messageA ...interface{}) {
+	bs.PublicURL = nil
+	memoryBrokerCondSet.Manage(bs).MarkFalse(MemoryBrokerExposure, reason, messageFormat, messageA...)
+}
+
+// IsHTTPRouteCreated returns whether the controller created an HTTPRoute for
+// the broker.
+func (bs *MemoryBrokerStatus) IsHTTPRouteCreated() bool {
+	return bs.HTTPRouteCreated
+}
+
+func (bs *MemoryBrokerStatus) SetHTTPRouteCreated(created bool) {
+	bs.HTTPRouteCreated = created
+}
@@ -6,6 +6,8 @@ package v1alpha1
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
 
@@ -39,17 +41,20 @@ var (
 	_ ReconcilableBroker = (*MemoryBroker)(nil)
 	// Check that the type conforms to the duck Knative Resource shape.
 	_ duckv1.KRShaped = (*MemoryBroker)(nil)
+	// Check that the type can be validated and defaulted.
+	_ apis.Validatable = (*MemoryBroker)(nil)
+	_ apis.Defaultable = (*MemoryBroker)(nil)
 )
 
 type Memory struct {
 	// Maximum number of items the stream can host.
-	BufferSize *int `json:"streamMaxLen,omitempty"`
+	BufferSize *int `json:"bufferSize,omitempty"`
 }
 
 type MemoryBrokerSpec struct {
 	Memory *Memory `json:"memory,omitempty"`
 
-	Broker Broker `json:"broker,omitempty"`
+	Broker CommonBrokerSpec `json:"broker,omitempty"`
 }
 
 // MemoryBrokerStatus represents the current state of a Memory broker.
@@ -63,6 +68,26 @@ type MemoryBrokerStatus struct {
 	// delivered into the Broker mesh.
 	// +optional
 	Address duckv1.Addressable `json:"address,omitempty"`
+
+	// Addresses lists the addresses the broker can be reached at.
+	// +optional
+	Addresses []duckv1.Addressable `json:"addresses,omitempty"`
+
+	// PublicURL is the address where the broker is reachable from outside
+	// the cluster.
+	// +optional
+	PublicURL *apis.URL `json:"publicURL,omitempty"`
+
+	// HTTPRouteCreated is set when the controller created a Gateway API
+	// HTTPRoute for the broker that needs to be removed when no longer
+	// configured.
+	// +optional
+	HTTPRouteCreated bool `json:"httpRouteCreated,omitempty"`
+
+	// DeliveryStatus contains the resolved URL to the broker level dead
+	// letter sink.
+	// +optional
+	eventingduckv1.DeliveryStatus `json:",inline"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -0,0 +1,28 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+
+	"knative.dev/pkg/apis"
+)
+
+// Validate the MemoryBroker.
+func (mb *MemoryBroker) Validate(ctx context.Context) *apis.FieldError {
+	ctx = apis.WithinParent(ctx, mb.ObjectMeta)
+	return mb.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec")
+}
+
+// Validate the MemoryBrokerSpec.
+func (mbs *MemoryBrokerSpec) Validate(ctx context.Context) *apis.FieldError {
+	errs := mbs.Broker.Validate(ctx).ViaField("broker")
+
+	if mbs.Memory != nil && mbs.Memory.BufferSize != nil && *mbs.Memory.BufferSize < 1 {
+		errs = errs.Also(apis.ErrInvalidValue(*mbs.Memory.BufferSize, "bufferSize",
+			"must be a positive number").ViaField("memory"))
+	}
+
+	return errs
+}
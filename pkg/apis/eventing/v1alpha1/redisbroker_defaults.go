@@ -0,0 +1,41 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+)
+
+// SetDefaults sets default values for the RedisBroker.
+func (rb *RedisBroker) SetDefaults(ctx context.Context) {
+	rb.Spec.SetDefaults(apis.WithinParent(ctx, rb.ObjectMeta))
+}
+
+// SetDefaults sets default values for the RedisBrokerSpec.
+func (rbs *RedisBrokerSpec) SetDefaults(ctx context.Context) {
+	rbs.Broker.SetDefaults(ctx)
+
+	if rbs.Redis == nil {
+		return
+	}
+
+	if p := rbs.Redis.Persistence; p != nil && p.AccessMode == "" {
+		p.AccessMode = corev1.ReadWriteOnce
+	}
+
+	if rbs.Redis.Connection == nil {
+		return
+	}
+
+	// When a CA certificate is provided it is used to verify the
+	// Redis server, which takes precedence over skipping verification.
+	c := rbs.Redis.Connection
+	if c.TLSCACertificate != nil && c.TLSSkipVerify != nil && *c.TLSSkipVerify {
+		skip := false
+		c.TLSSkipVerify = &skip
+	}
+}
@@ -30,6 +30,11 @@ const (
 	RedisBrokerConfigSecret                         apis.ConditionType = "BrokerConfigSecretReady"
 	RedisBrokerConditionAddressable                 apis.ConditionType = "Addressable"
 	RedisBrokerStatusConfig                         apis.ConditionType = "BrokerStatusConfigReady"
+	RedisBrokerDeadLetterSinkResolved               apis.ConditionType = "DeadLetterSinkResolved"
+	RedisBrokerReferencesResolved                   apis.ConditionType = "ReferencesResolved"
+	RedisBrokerExposure                             apis.ConditionType = "ExposureReady"
+	RedisBrokerRedisPersistence                     apis.ConditionType = "RedisPersistenceReady"
+	RedisBrokerRedisReachable                       apis.ConditionType = "RedisReachable"
 
 	RedisBrokerReasonUserProvided string = "ReasonUserProvidedRedis"
 )
@@ -46,6 +51,11 @@ var redisBrokerCondSet = apis.NewLivingConditionSet(
 	RedisBrokerConfigSecret,
 	RedisBrokerConditionAddressable,
 	RedisBrokerStatusConfig,
+	RedisBrokerDeadLetterSinkResolved,
+	RedisBrokerReferencesResolved,
+	RedisBrokerExposure,
+	RedisBrokerRedisPersistence,
+	RedisBrokerRedisReachable,
 )
 var redisBrokerCondSetLock = sync.RWMutex{}
 
@@ -60,7 +70,7 @@ func (t *RedisBroker) GetStatus() *duckv1.Status {
 }
 
 // GetReconcilableBrokerSpec returns the all brokers common Broker spec.
-func (t *RedisBroker) GetReconcilableBrokerSpec() *Broker {
+func (t *RedisBroker) GetReconcilableBrokerSpec() *CommonBrokerSpec {
 	return &t.Spec.Broker
 }
 
@@ -83,6 +93,31 @@ func (b *RedisBroker) GetConditionSet() apis.ConditionSet {
 	return redisBrokerCondSet
 }
 
+// GetTriggerConsumerStatus returns the consumer group status for the Trigger
+// configuration key, or nil if unknown.
+func (b *RedisBroker) GetTriggerConsumerStatus(key string) *TriggerConsumerStatus {
+	if b.Status.Stream == nil {
+		return nil
+	}
+
+	for _, cg := range b.Status.Stream.ConsumerGroups {
+		if cg.Trigger == key {
+			return &TriggerConsumerStatus{
+				Pending: cg.Pending,
+				Lag:     cg.Lag,
+			}
+		}
+	}
+
+	return nil
+}
+
+// IsRedisDeletionPolicyDelete returns if the stream and consumer groups must be
+// removed from the user provided Redis when the broker or its Triggers are deleted.
+func (b *RedisBroker) IsRedisDeletionPolicyDelete() bool {
+	return b.IsUserProvidedRedis() && b.Spec.Redis.DeletionPolicy == RedisDeletionPolicyDelete
+}
+
 // IsExternalRedis returns if the Redis instance is user provided.
 func (b *RedisBroker) IsUserProvidedRedis() bool {
 	if b.Spec.Redis != nil && b.Spec.Redis.Connection != nil {
@@ -115,6 +150,16 @@ func (bs *RedisBrokerStatus) SetAddress(url *apis.URL) {
 	}
 }
 
+// GetAddress returns the URI where the broker is reachable.
+func (bs *RedisBrokerStatus) GetAddress() *apis.URL {
+	return bs.Address.URL
+}
+
+// GetAddresses returns the addresses the broker can be reached at.
+func (bs *RedisBrokerStatus) GetAddresses() []duckv1.Addressable {
+	return bs.Addresses
+}
+
 // GetCondition returns the condition currently associated with the given type, or nil.
 func (bs *RedisBrokerStatus) GetCondition(t apis.ConditionType) *apis.Condition {
 	return bs.GetConditionSet().Manage(bs).GetCondition(t)
@@ -289,4 +334,123 @@ func (bs *RedisBrokerStatus) MarkRedisUserProvided() {
 	redisBrokerCondSet.Manage(bs).MarkTrueWithReason(RedisBrokerRedisDeployment, RedisBrokerReasonUserProvided, "Redis instance is externally provided")
 	redisBrokerCondSet.Manage(bs).MarkTrueWithReason(RedisBrokerRedisService, RedisBrokerReasonUserProvided, "Redis instance is externally provided")
 	redisBrokerCondSet.Manage(bs).MarkTrueWithReason(RedisBrokerRedisServiceEndpointsConditionReady, RedisBrokerReasonUserProvided, "Redis instance is externally provided")
+	redisBrokerCondSet.Manage(bs).MarkTrueWithReason(RedisBrokerRedisPersistence, RedisBrokerReasonUserProvided, "Redis instance is externally provided")
+}
+
+// Manage managed Redis persistence.
+
+func (bs *RedisBrokerStatus) MarkRedisPersistenceFailed(reason, messageFormat string, messageA ...interface{}) {
+	redisBrokerCondSet.Manage(bs).MarkFalse(RedisBrokerRedisPersistence, reason, messageFormat, messageA...)
+}
+
+func (bs *RedisBrokerStatus) MarkRedisPersistenceUnknown(reason, messageFormat string, messageA ...interface{}) {
+	redisBrokerCondSet.Manage(bs).MarkUnknown(RedisBrokerRedisPersistence, reason, messageFormat, messageA...)
+}
+
+func (bs *RedisBrokerStatus) MarkRedisPersistenceNotConfigured() {
+	redisBrokerCondSet.Manage(bs).MarkTrueWithReason(RedisBrokerRedisPersistence,
+		"PersistenceNotConfigured", "Redis data is not persisted.")
+}
+
+func (bs *RedisBrokerStatus) PropagateRedisPersistentVolumeClaimStatus(pvcs *corev1.PersistentVolumeClaimStatus) {
+	switch pvcs.Phase {
+	case corev1.ClaimBound:
+		redisBrokerCondSet.Manage(bs).MarkTrue(RedisBrokerRedisPersistence)
+	case corev1.ClaimLost:
+		bs.MarkRedisPersistenceFailed("PersistentVolumeClaimLost", "The Redis PersistentVolumeClaim lost its volume")
+	default:
+		// expected corev1.ClaimPending
+		bs.MarkRedisPersistenceUnknown("PersistentVolumeClaimPending", "The Redis PersistentVolumeClaim is not bound yet")
+	}
+}
+
+// Manage connectivity to the Redis instance.
+
+func (bs *RedisBrokerStatus) MarkRedisReachable() {
+	redisBrokerCondSet.Manage(bs).MarkTrue(RedisBrokerRedisReachable)
+}
+
+func (bs *RedisBrokerStatus) MarkRedisReachableWithReason(reason, messageFormat string, messageA ...interface{}) {
+	redisBrokerCondSet.Manage(bs).MarkTrueWithReason(RedisBrokerRedisReachable, reason, messageFormat, messageA...)
+}
+
+func (bs *RedisBrokerStatus) MarkRedisReachableFailed(reason, messageFormat string, messageA ...interface{}) {
+	redisBrokerCondSet.Manage(bs).MarkFalse(RedisBrokerRedisReachable, reason, messageFormat, messageA...)
+}
+
+func (bs *RedisBrokerStatus) MarkRedisReachableNotChecked() {
+	redisBrokerCondSet.Manage(bs).MarkTrueWithReason(RedisBrokerRedisReachable,
+		"RedisManaged", "Managed Redis availability is reported by its endpoints.")
+}
+
+// Manage broker level dead letter sink.
+
+// GetDeadLetterSinkURI returns the resolved broker level dead letter sink.
+func (bs *RedisBrokerStatus) GetDeadLetterSinkURI() *apis.URL {
+	return bs.DeadLetterSinkURI
+}
+
+func (bs *RedisBrokerStatus) MarkDeadLetterSinkResolvedSucceeded(uri *apis.URL) {
+	bs.DeadLetterSinkURI = uri
+	redisBrokerCondSet.Manage(bs).MarkTrue(RedisBrokerDeadLetterSinkResolved)
+}
+
+func (bs *RedisBrokerStatus) MarkDeadLetterSinkNotConfigured() {
+	bs.DeadLetterSinkURI = nil
+	redisBrokerCondSet.Manage(bs).MarkTrueWithReason(RedisBrokerDeadLetterSinkResolved,
+		"DeadLetterSinkNotConfigured", "No dead letter sink is configured.")
+}
+
+func (bs *RedisBrokerStatus) MarkDeadLetterSinkResolvedFailed(reason, messageFormat string, messageA ...interface{}) {
+	bs.DeadLetterSinkURI = nil
+	redisBrokerCondSet.Manage(bs).MarkFalse(RedisBrokerDeadLetterSinkResolved, reason, messageFormat, messageA...)
+}
+
+// Manage user referenced Secrets and ConfigMaps.
+
+func (bs *RedisBrokerStatus) MarkReferencesResolved() {
+	redisBrokerCondSet.Manage(bs).MarkTrue(RedisBrokerReferencesResolved)
+}
+
+func (bs *RedisBrokerStatus) MarkReferencesResolvedFailed(reason, messageFormat string, messageA ...interface{}) {
+	redisBrokerCondSet.Manage(bs).MarkFalse(RedisBrokerReferencesResolved, reason, messageFormat, messageA...)
+}
+
+// Manage broker external exposure.
+
+// GetPublicURL returns the URL where the broker is reachable from outside the
+// cluster.
+func (bs *RedisBrokerStatus) GetPublicURL() *apis.URL {
+	return bs.PublicURL
+}
+
+func (bs *RedisBrokerStatus) MarkExposureReady(publicURL *apis.URL) {
+	bs.PublicURL = publicURL
+	redisBrokerCondSet.Manage(bs).MarkTrue(RedisBrokerExposure)
+}
+
+func (bs *RedisBrokerStatus) MarkExposureNotConfigured() {
+	bs.PublicURL = nil
+	redisBrokerCondSet.Manage(bs).MarkTrueWithReason(RedisBrokerExposure,
+		"ExposureNotConfigured", "The broker is not exposed outside the cluster.")
+}
+
+func (bs *RedisBrokerStatus) MarkExposureUnknown(reason, messageFormat string, messageA ...interface{}) {
+	bs.PublicURL = nil
+	redisBrokerCondSet.Manage(bs).MarkUnknown(RedisBrokerExposure, reason, messageFormat, messageA...)
+}
+
+func (bs *RedisBrokerStatus) MarkExposureFailed(reason, messageFormat string, messageA ...interface{}) {
+	bs.PublicURL = nil
+	redisBrokerCondSet.Manage(bs).MarkFalse(RedisBrokerExposure, reason, messageFormat, messageA...)
+}
+
+// IsHTTPRouteCreated returns whether the controller created an HTTPRoute for
+// the broker.
+func (bs *RedisBrokerStatus) IsHTTPRouteCreated() bool {
+	return bs.HTTPRouteCreated
+}
+
+func (bs *RedisBrokerStatus) SetHTTPRouteCreated(created bool) {
+	bs.HTTPRouteCreated = created
 }
@@ -5,8 +5,11 @@ package v1alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
 
@@ -40,6 +43,9 @@ var (
 	_ ReconcilableBroker = (*RedisBroker)(nil)
 	// Check that the type conforms to the duck Knative Resource shape.
 	_ duckv1.KRShaped = (*RedisBroker)(nil)
+	// Check that the type can be validated and defaulted.
+	_ apis.Validatable = (*RedisBroker)(nil)
+	_ apis.Defaultable = (*RedisBroker)(nil)
 )
 
 type RedisConnection struct {
@@ -81,8 +87,76 @@ type Redis struct {
 	// Maximum number of items the stream can host.
 	StreamMaxLen *int `json:"streamMaxLen,omitempty"`
 
+	// Retention trims the stream by age and memory usage, and can be
+	// combined with StreamMaxLen.
+	// +optional
+	Retention *RedisRetention `json:"retention,omitempty"`
+
 	// Whether the Redis ID for the event is added as a CloudEvents attribute.
 	EnableTrackingID *bool `json:"enableTrackingID,omitempty"`
+
+	// PodTemplate customizes the managed Redis pods. It cannot be used
+	// along with a user provided connection.
+	// +optional
+	PodTemplate *PodTemplate `json:"podTemplate,omitempty"`
+
+	// Persistence stores the managed Redis data in a PersistentVolumeClaim.
+	// It cannot be used along with a user provided connection.
+	// +optional
+	Persistence *RedisPersistence `json:"persistence,omitempty"`
+
+	// DeletionPolicy decides whether the stream and consumer groups are
+	// removed from the user provided Redis when the broker is deleted.
+	// Defaults to Retain.
+	// +optional
+	DeletionPolicy RedisDeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// RedisDeletionPolicy is the action applied to the broker's Redis stream
+// when the broker is deleted.
+type RedisDeletionPolicy string
+
+const (
+	// RedisDeletionPolicyRetain keeps the stream and consumer groups at Redis.
+	RedisDeletionPolicyRetain RedisDeletionPolicy = "Retain"
+	// RedisDeletionPolicyDelete removes the stream and consumer groups from Redis.
+	RedisDeletionPolicyDelete RedisDeletionPolicy = "Delete"
+)
+
+// RedisPersistence contains the parameters for the volume claimed to
+// store the managed Redis data.
+type RedisPersistence struct {
+	// StorageClassName for the claim. When not informed the cluster's
+	// default storage class is used.
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// Size of the volume.
+	Size resource.Quantity `json:"size"`
+
+	// AccessMode for the volume. Defaults to ReadWriteOnce.
+	// +optional
+	AccessMode corev1.PersistentVolumeAccessMode `json:"accessMode,omitempty"`
+}
+
+// RedisRetention contains the parameters for trimming the stream in addition
+// to its maximum length.
+type RedisRetention struct {
+	// MaxAge of the events kept at the stream formatted as an ISO 8601
+	// duration. Older events are trimmed.
+	// +optional
+	MaxAge *string `json:"maxAge,omitempty"`
+
+	// Exact trimming removes every event that exceeds the retention limits.
+	// Approximate trimming, the default, is more efficient but might keep
+	// some extra events.
+	// +optional
+	Exact *bool `json:"exact,omitempty"`
+
+	// MaxBytes is the memory the stream can use before the oldest events
+	// are trimmed.
+	// +optional
+	MaxBytes *resource.Quantity `json:"maxBytes,omitempty"`
 }
 
 // SecretValueFromSource represents the source of a secret value
@@ -94,7 +168,7 @@ type SecretValueFromSource struct {
 type RedisBrokerSpec struct {
 	Redis *Redis `json:"redis,omitempty"`
 
-	Broker Broker `json:"broker,omitempty"`
+	Broker CommonBrokerSpec `json:"broker,omitempty"`
 }
 
 // RedisBrokerStatus represents the current state of a Redis broker.
@@ -108,6 +182,77 @@ type RedisBrokerStatus struct {
 	// delivered into the Broker mesh.
 	// +optional
 	Address duckv1.Addressable `json:"address,omitempty"`
+
+	// Addresses lists the addresses the broker can be reached at.
+	// +optional
+	Addresses []duckv1.Addressable `json:"addresses,omitempty"`
+
+	// PublicURL is the address where the broker is reachable from outside
+	// the cluster.
+	// +optional
+	PublicURL *apis.URL `json:"publicURL,omitempty"`
+
+	// HTTPRouteCreated is set when the controller created a Gateway API
+	// HTTPRoute for the broker that needs to be removed when no longer
+	// configured.
+	// +optional
+	HTTPRouteCreated bool `json:"httpRouteCreated,omitempty"`
+
+	// DeliveryStatus contains the resolved URL to the broker level dead
+	// letter sink.
+	// +optional
+	eventingduckv1.DeliveryStatus `json:",inline"`
+
+	// Stream reports the state of the Redis stream backing the broker.
+	// +optional
+	Stream *RedisStreamStatus `json:"stream,omitempty"`
+}
+
+// RedisStreamStatus reports the state of the Redis stream backing the broker.
+type RedisStreamStatus struct {
+	// Name of the Redis stream.
+	Name string `json:"name"`
+
+	// Number of entries at the stream.
+	Length int64 `json:"length"`
+
+	// Identifier of the oldest entry at the stream.
+	// +optional
+	FirstEntryID string `json:"firstEntryId,omitempty"`
+
+	// Identifier of the newest entry at the stream.
+	// +optional
+	LastEntryID string `json:"lastEntryId,omitempty"`
+
+	// Consumer groups created by the broker for each Trigger.
+	// +optional
+	ConsumerGroups []RedisConsumerGroupStatus `json:"consumerGroups,omitempty"`
+
+	// Time when the stream state was last read from Redis.
+	// +optional
+	LastRefreshTime *metav1.Time `json:"lastRefreshTime,omitempty"`
+}
+
+// RedisConsumerGroupStatus reports the state of a Trigger's consumer group.
+type RedisConsumerGroupStatus struct {
+	// Name of the consumer group.
+	Name string `json:"name"`
+
+	// Trigger key at the broker configuration, the Trigger name for Triggers
+	// at the broker's namespace, or namespace/name for other namespaces.
+	Trigger string `json:"trigger"`
+
+	// Number of entries delivered to the consumer group that have not been
+	// acknowledged yet.
+	Pending int64 `json:"pending"`
+
+	// Number of entries at the stream that have not been delivered to the
+	// consumer group yet.
+	Lag int64 `json:"lag"`
+
+	// Identifier of the last entry delivered to the consumer group.
+	// +optional
+	LastDeliveredID string `json:"lastDeliveredId,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -0,0 +1,228 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/rickb777/date/period"
+
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+)
+
+// Validate the RedisBroker.
+func (rb *RedisBroker) Validate(ctx context.Context) *apis.FieldError {
+	ctx = apis.WithinParent(ctx, rb.ObjectMeta)
+	return rb.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec")
+}
+
+// Validate the RedisBrokerSpec.
+func (rbs *RedisBrokerSpec) Validate(ctx context.Context) *apis.FieldError {
+	errs := rbs.Broker.Validate(ctx).ViaField("broker")
+
+	if rbs.Redis != nil {
+		errs = errs.Also(rbs.Redis.Validate(ctx).ViaField("redis"))
+	}
+
+	return errs
+}
+
+// Validate the Redis parameters.
+func (r *Redis) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	if r.StreamMaxLen != nil && *r.StreamMaxLen < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*r.StreamMaxLen, "streamMaxLen",
+			"must be 0 for unlimited, or a positive number"))
+	}
+
+	// Retention limits are applied independently, they can be combined
+	// with the stream maximum length.
+	if r.Retention != nil {
+		errs = errs.Also(r.Retention.Validate(ctx).ViaField("retention"))
+	}
+
+	if r.Stream != nil && *r.Stream == "" {
+		errs = errs.Also(apis.ErrInvalidValue(*r.Stream, "stream",
+			"must not be empty when informed"))
+	}
+
+	if r.Connection != nil {
+		errs = errs.Also(r.Connection.Validate(ctx).ViaField("connection"))
+
+		if r.PodTemplate != nil {
+			errs = errs.Also(&apis.FieldError{
+				Message: "managed Redis pods cannot be customized when a connection is informed",
+				Paths:   []string{"podTemplate"},
+			})
+		}
+
+		if r.Persistence != nil {
+			errs = errs.Also(&apis.FieldError{
+				Message: "managed Redis persistence cannot be configured when a connection is informed",
+				Paths:   []string{"persistence"},
+			})
+		}
+	}
+
+	switch r.DeletionPolicy {
+	case "", RedisDeletionPolicyRetain:
+	case RedisDeletionPolicyDelete:
+		if r.Connection == nil {
+			errs = errs.Also(&apis.FieldError{
+				Message: "deletion policy Delete requires a user provided connection, managed Redis is removed along with the broker",
+				Paths:   []string{"deletionPolicy"},
+			})
+		}
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(r.DeletionPolicy, "deletionPolicy",
+			"must be one of Retain or Delete"))
+	}
+
+	if r.Persistence != nil {
+		errs = errs.Also(r.Persistence.Validate(ctx).ViaField("persistence"))
+	}
+
+	if r.PodTemplate != nil {
+		errs = errs.Also(r.PodTemplate.Validate(ctx).ViaField("podTemplate"))
+	}
+
+	return errs
+}
+
+// Validate the Redis connection parameters.
+func (rc *RedisConnection) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	hasURL := rc.URL != nil
+	hasClusterURLs := len(rc.ClusterURLs) != 0
+	switch {
+	case hasURL && hasClusterURLs:
+		errs = errs.Also(apis.ErrMultipleOneOf("url", "clusterURLs"))
+	case !hasURL && !hasClusterURLs:
+		errs = errs.Also(apis.ErrMissingOneOf("url", "clusterURLs"))
+	case hasURL && *rc.URL == "":
+		errs = errs.Also(apis.ErrInvalidValue(*rc.URL, "url", "must not be empty"))
+	}
+
+	if hasURL {
+		errs = errs.Also(validateNotSentinelURL(*rc.URL).ViaField("url"))
+	}
+
+	for i, u := range rc.ClusterURLs {
+		if u == "" {
+			errs = errs.Also(apis.ErrInvalidArrayValue(u, "clusterURLs", i))
+			continue
+		}
+		errs = errs.Also(validateNotSentinelURL(u).ViaFieldIndex("clusterURLs", i))
+	}
+
+	errs = errs.Also(rc.Username.Validate(ctx).ViaField("username")).
+		Also(rc.Password.Validate(ctx).ViaField("password")).
+		Also(rc.TLSCACertificate.Validate(ctx).ViaField("tlsCACertificate")).
+		Also(rc.TLSCertificate.Validate(ctx).ViaField("tlsCertificate")).
+		Also(rc.TLSKey.Validate(ctx).ViaField("tlsKey"))
+
+	// Client certificate authentication requires both the certificate and its key.
+	switch {
+	case rc.TLSKey != nil && rc.TLSCertificate == nil:
+		errs = errs.Also(apis.ErrMissingField("tlsCertificate"))
+	case rc.TLSCertificate != nil && rc.TLSKey == nil:
+		errs = errs.Also(apis.ErrMissingField("tlsKey"))
+	}
+
+	return errs
+}
+
+// validateNotSentinelURL rejects connection URLs that point to Redis Sentinel,
+// which the broker is not able to use.
+func validateNotSentinelURL(u string) *apis.FieldError {
+	// Addresses without a scheme are host:port pairs, which the URL parser
+	// would otherwise read as scheme:opaque.
+	if !strings.Contains(u, "://") {
+		return nil
+	}
+
+	pu, err := url.Parse(u)
+	if err != nil {
+		return nil
+	}
+
+	var isSentinel bool
+	switch strings.ToLower(pu.Scheme) {
+	case "sentinel", "redis+sentinel", "rediss+sentinel":
+		isSentinel = true
+	}
+	for k := range pu.Query() {
+		k = strings.ToLower(k)
+		if k == "master_name" || strings.HasPrefix(k, "sentinel") {
+			isSentinel = true
+		}
+	}
+
+	if isSentinel {
+		return apis.ErrInvalidValue(u, apis.CurrentField,
+			"Redis Sentinel is not supported, use clusterURLs for highly available setups")
+	}
+	return nil
+}
+
+// Validate the secret reference, nil references are considered valid.
+func (s *SecretValueFromSource) Validate(ctx context.Context) *apis.FieldError {
+	if s == nil {
+		return nil
+	}
+
+	var errs *apis.FieldError
+	if s.SecretKeyRef.Name == "" {
+		errs = errs.Also(apis.ErrMissingField("name"))
+	}
+	if s.SecretKeyRef.Key == "" {
+		errs = errs.Also(apis.ErrMissingField("key"))
+	}
+
+	return errs.ViaField("secretKeyRef")
+}
+
+// Validate the Redis retention parameters.
+func (rr *RedisRetention) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	if rr.MaxAge != nil {
+		p, err := period.Parse(*rr.MaxAge)
+		switch {
+		case err != nil:
+			errs = errs.Also(apis.ErrInvalidValue(*rr.MaxAge, "maxAge", err.Error()))
+		case p.IsNegative() || p.IsZero():
+			errs = errs.Also(apis.ErrInvalidValue(*rr.MaxAge, "maxAge", "must be greater than zero"))
+		}
+	}
+
+	if rr.MaxBytes != nil && rr.MaxBytes.Sign() <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(rr.MaxBytes.String(), "maxBytes", "must be greater than zero"))
+	}
+
+	return errs
+}
+
+// Validate the Redis persistence parameters.
+func (rp *RedisPersistence) Validate(ctx context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+
+	if rp.Size.Sign() <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(rp.Size.String(), "size", "must be greater than zero"))
+	}
+
+	switch rp.AccessMode {
+	case "", corev1.ReadWriteOnce, corev1.ReadWriteOncePod, corev1.ReadWriteMany:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(rp.AccessMode, "accessMode",
+			"must be one of ReadWriteOnce, ReadWriteOncePod or ReadWriteMany"))
+	}
+
+	return errs
+}
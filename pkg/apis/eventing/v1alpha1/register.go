@@ -33,6 +33,10 @@ func Resource(resource string) schema.GroupResource {
 // Adds the list of known types to Scheme.
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
+		&Broker{},
+		&BrokerList{},
+		&KafkaBroker{},
+		&KafkaBrokerList{},
 		&MemoryBroker{},
 		&MemoryBrokerList{},
 		&RedisBroker{},
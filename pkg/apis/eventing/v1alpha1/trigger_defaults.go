@@ -0,0 +1,33 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"knative.dev/pkg/apis"
+)
+
+// SetDefaults sets default values for the Trigger.
+func (t *Trigger) SetDefaults(ctx context.Context) {
+	t.Spec.SetDefaults(apis.WithinParent(ctx, t.ObjectMeta))
+}
+
+// SetDefaults sets default values for the TriggerSpec.
+func (ts *TriggerSpec) SetDefaults(ctx context.Context) {
+	if ts.Broker.Group == "" {
+		ts.Broker.Group = SchemeGroupVersion.Group
+		if ts.Broker.APIVersion != "" {
+			if gv, err := schema.ParseGroupVersion(ts.Broker.APIVersion); err == nil {
+				ts.Broker.Group = gv.Group
+			}
+		}
+	}
+	ts.Broker.SetDefaults(ctx)
+
+	ts.Target.SetDefaults(ctx)
+	ts.Delivery.SetDefaults(ctx)
+}
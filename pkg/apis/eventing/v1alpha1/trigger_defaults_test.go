@@ -0,0 +1,91 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestTriggerDefaultsAndValidation(t *testing.T) {
+	testCases := map[string]struct {
+		broker        duckv1.KReference
+		expectedGroup string
+		expectedError bool
+	}{
+		"no group nor API version": {
+			broker: duckv1.KReference{
+				Kind: "RedisBroker",
+				Name: tBrokerName,
+			},
+			expectedGroup: "eventing.triggermesh.io",
+		},
+		"API version informed": {
+			broker: duckv1.KReference{
+				APIVersion: "example.com/v1",
+				Kind:       "RedisBroker",
+				Name:       tBrokerName,
+			},
+			expectedGroup: "example.com",
+		},
+		"broker at different namespace": {
+			broker: duckv1.KReference{
+				Kind:      "RedisBroker",
+				Name:      tBrokerName,
+				Namespace: "other",
+			},
+			expectedGroup: "eventing.triggermesh.io",
+		},
+		"invalid broker reference": {
+			broker: duckv1.KReference{
+				Kind: "RedisBroker",
+			},
+			expectedGroup: "eventing.triggermesh.io",
+			expectedError: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			trg := &Trigger{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: tNamespace,
+				},
+				Spec: TriggerSpecBounded{
+					TriggerSpec: TriggerSpec{
+						Broker: tc.broker,
+						Target: duckv1.Destination{
+							Ref: &duckv1.KReference{
+								APIVersion: "v1",
+								Kind:       "Service",
+								Name:       "target",
+							},
+						},
+					},
+				},
+			}
+
+			ctx := duckv1.KReferenceGroupAllowed(context.Background())
+			trg.SetDefaults(ctx)
+
+			assert.Equal(t, tc.expectedGroup, trg.Spec.Broker.Group)
+			assert.Equal(t, tNamespace, trg.Spec.Target.Ref.Namespace)
+			if tc.broker.Namespace == "" {
+				assert.Equal(t, tNamespace, trg.Spec.Broker.Namespace)
+			}
+
+			err := trg.Validate(ctx)
+			if tc.expectedError {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
@@ -126,12 +126,49 @@ func (ts *TriggerStatus) MarkDeadLetterSinkResolvedFailed(reason, messageFormat
 	triggerCondSet.Manage(ts).MarkFalse(TriggerConditionDeadLetterSinkResolved, reason, messageFormat, messageA...)
 }
 
+// BrokerNamespace returns the namespace of the Broker referenced by the
+// Trigger, defaulting to the Trigger's namespace when not informed.
+func (t *Trigger) BrokerNamespace() string {
+	if t.Spec.Broker.Namespace != "" {
+		return t.Spec.Broker.Namespace
+	}
+	return t.Namespace
+}
+
+// ConfigKey returns the key that identifies the Trigger at the broker
+// configuration and status. Triggers that live at a different namespace than
+// the broker are prefixed with their namespace so that names do not collide.
+func (t *Trigger) ConfigKey() string {
+	if t.Namespace == t.BrokerNamespace() {
+		return t.Name
+	}
+	return t.Namespace + "/" + t.Name
+}
+
+// ReferencesClassBroker returns whether the Trigger references a class based
+// Broker instead of a concrete broker kind.
+func (t *Trigger) ReferencesClassBroker() bool {
+	gvk := (&Broker{}).GetGroupVersionKind()
+
+	if t.Spec.Broker.APIVersion != "" {
+		if t.Spec.Broker.APIVersion != gvk.GroupVersion().String() {
+			return false
+		}
+	} else if t.Spec.Broker.Group != gvk.Group {
+		return false
+	}
+
+	return t.Spec.Broker.Kind == gvk.Kind
+}
+
+// OwnerRefableMatchesBroker returns whether the broker is the one referenced by
+// the Trigger. Matching a class based reference needs the Broker to know which
+// class serves it, use OwnerRefableMatchesClassBroker instead.
 func (t *Trigger) OwnerRefableMatchesBroker(broker kmeta.OwnerRefable) bool {
 	gvk := broker.GetGroupVersionKind()
 
-	// Require same namespace for Trigger and Broker.
-	if t.Spec.Broker.Namespace != "" &&
-		t.Spec.Broker.Namespace != broker.GetObjectMeta().GetNamespace() {
+	if t.BrokerNamespace() != broker.GetObjectMeta().GetNamespace() ||
+		t.ReferencesClassBroker() {
 		return false
 	}
 
@@ -148,7 +185,15 @@ func (t *Trigger) OwnerRefableMatchesBroker(broker kmeta.OwnerRefable) bool {
 		t.Spec.Broker.Kind == gvk.Kind
 }
 
+// OwnerReferenceMatchesBroker returns whether the owner reference points to the
+// broker referenced by the Trigger. The owner reference does not tell which
+// Broker controls the broker that serves it, class based references need the
+// serving broker to be resolved and matched using OwnerReferenceMatchesClassBroker.
 func (t *Trigger) OwnerReferenceMatchesBroker(broker metav1.OwnerReference) bool {
+	if t.ReferencesClassBroker() {
+		return false
+	}
+
 	if t.Spec.Broker.APIVersion != "" && t.Spec.Broker.APIVersion != broker.APIVersion {
 		return false
 	}
@@ -167,6 +212,40 @@ func (t *Trigger) OwnerReferenceMatchesBroker(broker metav1.OwnerReference) bool
 		t.Spec.Broker.Kind == broker.Kind
 }
 
+// OwnerRefableMatchesClassBroker returns whether the broker serves the class
+// based Broker referenced by the Trigger. The serving broker must be of the
+// Broker's class and controlled by the Broker.
+func (t *Trigger) OwnerRefableMatchesClassBroker(b *Broker, served kmeta.OwnerRefable) bool {
+	if !t.ReferencesClassBroker() ||
+		b.Namespace != t.BrokerNamespace() || b.Name != t.Spec.Broker.Name {
+		return false
+	}
+
+	meta := served.GetObjectMeta()
+
+	return served.GetGroupVersionKind().Kind == string(b.Spec.Class) &&
+		meta.GetNamespace() == b.Namespace &&
+		meta.GetName() == b.Name &&
+		metav1.IsControlledBy(meta, b)
+}
+
+// OwnerReferenceMatchesClassBroker returns whether the owner reference points to
+// the broker that serves the class based Broker referenced by the Trigger. The
+// serving broker must be of the Broker's class and controlled by the Broker.
+func (t *Trigger) OwnerReferenceMatchesClassBroker(ref metav1.OwnerReference, b *Broker, served kmeta.OwnerRefable) bool {
+	if !t.OwnerRefableMatchesClassBroker(b, served) {
+		return false
+	}
+
+	gvk := served.GetGroupVersionKind()
+	meta := served.GetObjectMeta()
+
+	return ref.APIVersion == gvk.GroupVersion().String() &&
+		ref.Kind == gvk.Kind &&
+		ref.Name == meta.GetName() &&
+		ref.UID == meta.GetUID()
+}
+
 func (ts *TriggerStatus) MarkStatusConfigMapFailed(reason, messageFormat string, messageA ...interface{}) {
 	triggerCondSet.Manage(ts).MarkFalse(TriggerConditionStatusConfigMap, reason, messageFormat, messageA...)
 }
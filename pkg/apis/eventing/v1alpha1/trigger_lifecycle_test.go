@@ -9,6 +9,8 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/ptr"
 )
 
 const (
@@ -28,6 +30,30 @@ func TestDoesTriggerRefBroker(t *testing.T) {
 		},
 	}
 
+	// RedisBroker that serves a class based Broker.
+	crb := rb.DeepCopy()
+	crb.OwnerReferences = []metav1.OwnerReference{{
+		APIVersion: SchemeGroupVersion.String(),
+		Kind:       "Broker",
+		Name:       tBrokerName,
+		Controller: ptr.Bool(true),
+	}}
+
+	classBrokerTrigger := &Trigger{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: tNamespace,
+		},
+		Spec: TriggerSpecBounded{
+			TriggerSpec: TriggerSpec{
+				Broker: duckv1.KReference{
+					Group: "eventing.triggermesh.io",
+					Kind:  "Broker",
+					Name:  tBrokerName,
+				},
+			},
+		},
+	}
+
 	testCases := map[string]struct {
 		trigger  *Trigger
 		broker   *RedisBroker
@@ -123,6 +149,43 @@ func TestDoesTriggerRefBroker(t *testing.T) {
 			broker:   rb,
 			expected: false,
 		},
+		"trigger at other namespace without broker namespace": {
+			trigger: &Trigger{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "other",
+				},
+				Spec: TriggerSpecBounded{
+					TriggerSpec: TriggerSpec{
+						Broker: duckv1.KReference{
+							Group: "eventing.triggermesh.io",
+							Kind:  "RedisBroker",
+							Name:  tBrokerName,
+						},
+					},
+				},
+			},
+			broker:   rb,
+			expected: false,
+		},
+		"trigger at other namespace with broker namespace": {
+			trigger: &Trigger{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "other",
+				},
+				Spec: TriggerSpecBounded{
+					TriggerSpec: TriggerSpec{
+						Broker: duckv1.KReference{
+							Group:     "eventing.triggermesh.io",
+							Kind:      "RedisBroker",
+							Name:      tBrokerName,
+							Namespace: tNamespace,
+						},
+					},
+				},
+			},
+			broker:   rb,
+			expected: true,
+		},
 		"missing group and APIVersion": {
 			trigger: &Trigger{
 				ObjectMeta: metav1.ObjectMeta{
@@ -140,6 +203,11 @@ func TestDoesTriggerRefBroker(t *testing.T) {
 			broker:   rb,
 			expected: false,
 		},
+		"class based broker is matched using the Broker": {
+			trigger:  classBrokerTrigger,
+			broker:   crb,
+			expected: false,
+		},
 	}
 
 	for name, tc := range testCases {
@@ -149,3 +217,190 @@ func TestDoesTriggerRefBroker(t *testing.T) {
 		})
 	}
 }
+
+func TestOwnerRefableMatchesClassBroker(t *testing.T) {
+	b := &Broker{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      tBrokerName,
+			Namespace: tNamespace,
+			UID:       "broker-uid",
+		},
+		Spec: BrokerSpec{Class: BrokerClassRedis},
+	}
+
+	served := &RedisBroker{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            tBrokerName,
+			Namespace:       tNamespace,
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(b)},
+		},
+	}
+
+	trigger := &Trigger{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: tNamespace,
+		},
+		Spec: TriggerSpecBounded{
+			TriggerSpec: TriggerSpec{
+				Broker: duckv1.KReference{
+					Group: "eventing.triggermesh.io",
+					Kind:  "Broker",
+					Name:  tBrokerName,
+				},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		trigger  func(*Trigger)
+		broker   func(*Broker)
+		served   func(*RedisBroker)
+		expected bool
+	}{
+		"served by the broker": {
+			expected: true,
+		},
+		"broker of a previous class": {
+			broker: func(b *Broker) {
+				b.Spec.Class = BrokerClassMemory
+			},
+			expected: false,
+		},
+		"not controlled by the broker": {
+			served: func(rb *RedisBroker) {
+				rb.OwnerReferences = nil
+			},
+			expected: false,
+		},
+		"broker with other name": {
+			served: func(rb *RedisBroker) {
+				rb.Name = "other"
+			},
+			expected: false,
+		},
+		"trigger references other broker": {
+			trigger: func(t *Trigger) {
+				t.Spec.Broker.Name = "other"
+			},
+			expected: false,
+		},
+		"trigger references a concrete broker kind": {
+			trigger: func(t *Trigger) {
+				t.Spec.Broker.Kind = "RedisBroker"
+			},
+			expected: false,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			trigger, b, served := trigger.DeepCopy(), b.DeepCopy(), served.DeepCopy()
+			if tc.trigger != nil {
+				tc.trigger(trigger)
+			}
+			if tc.broker != nil {
+				tc.broker(b)
+			}
+			if tc.served != nil {
+				tc.served(served)
+			}
+
+			assert.Equal(t, tc.expected, trigger.OwnerRefableMatchesClassBroker(b, served))
+		})
+	}
+}
+
+func TestOwnerReferenceMatchesClassBroker(t *testing.T) {
+	b := &Broker{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      tBrokerName,
+			Namespace: tNamespace,
+			UID:       "broker-uid",
+		},
+		Spec: BrokerSpec{Class: BrokerClassRedis},
+	}
+
+	served := &RedisBroker{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            tBrokerName,
+			Namespace:       tNamespace,
+			UID:             "served-uid",
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(b)},
+		},
+	}
+
+	ref := metav1.OwnerReference{
+		APIVersion: SchemeGroupVersion.String(),
+		Kind:       "RedisBroker",
+		Name:       tBrokerName,
+		UID:        "served-uid",
+	}
+
+	trigger := &Trigger{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: tNamespace,
+		},
+		Spec: TriggerSpecBounded{
+			TriggerSpec: TriggerSpec{
+				Broker: duckv1.KReference{
+					Group: "eventing.triggermesh.io",
+					Kind:  "Broker",
+					Name:  tBrokerName,
+				},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		ref      func(*metav1.OwnerReference)
+		broker   func(*Broker)
+		served   func(*RedisBroker)
+		expected bool
+	}{
+		"served by the broker": {
+			expected: true,
+		},
+		"kind does not match the class": {
+			broker: func(b *Broker) {
+				b.Spec.Class = BrokerClassKafka
+			},
+			expected: false,
+		},
+		"owner of other kind": {
+			ref: func(r *metav1.OwnerReference) {
+				r.Kind = "MemoryBroker"
+			},
+			expected: false,
+		},
+		"owner not controlled by the broker": {
+			served: func(rb *RedisBroker) {
+				rb.OwnerReferences = nil
+			},
+			expected: false,
+		},
+		"owner replaced": {
+			ref: func(r *metav1.OwnerReference) {
+				r.UID = "previous-uid"
+			},
+			expected: false,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			b, served, ref := b.DeepCopy(), served.DeepCopy(), ref
+			if tc.broker != nil {
+				tc.broker(b)
+			}
+			if tc.served != nil {
+				tc.served(served)
+			}
+			if tc.ref != nil {
+				tc.ref(&ref)
+			}
+
+			assert.Equal(t, tc.expected, trigger.OwnerReferenceMatchesClassBroker(ref, b, served))
+			assert.False(t, trigger.OwnerReferenceMatchesBroker(ref))
+		})
+	}
+}
@@ -42,8 +42,15 @@ var (
 	_ kmeta.OwnerRefable = (*Trigger)(nil)
 	// Check that the type conforms to the duck Knative Resource shape.
 	_ duckv1.KRShaped = (*Trigger)(nil)
+	// Check that the type can be validated and defaulted.
+	_ apis.Validatable = (*Trigger)(nil)
+	_ apis.Defaultable = (*Trigger)(nil)
 )
 
+// BackoffPolicyConstant retries using the same delay between attempts. It is
+// supported by TriggerMesh brokers in addition to Knative's backoff policies.
+const BackoffPolicyConstant eventingduckv1.BackoffPolicyType = "constant"
+
 // TriggerSpec defines the desired state of Trigger
 type TriggerSpec struct {
 	// Broker is the broker that this trigger receives events from.
@@ -115,6 +122,23 @@ type TriggerStatus struct {
 	// DeliveryStatus contains a resolved URL to the dead letter sink address, and any other
 	// resolved delivery options.
 	eventingduckv1.DeliveryStatus `json:",inline"`
+
+	// Consumer reports the events waiting to be delivered to this Trigger,
+	// for brokers that support it.
+	// +optional
+	Consumer *TriggerConsumerStatus `json:"consumer,omitempty"`
+}
+
+// TriggerConsumerStatus reports the events at the broker that are waiting to
+// be delivered to a Trigger.
+type TriggerConsumerStatus struct {
+	// Number of events delivered to the Trigger that have not been
+	// acknowledged yet.
+	Pending int64 `json:"pending"`
+
+	// Number of events at the broker that have not been delivered to the
+	// Trigger yet.
+	Lag int64 `json:"lag"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
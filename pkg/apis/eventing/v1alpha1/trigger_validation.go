@@ -7,24 +7,39 @@ import (
 	"context"
 
 	"github.com/triggermesh/brokers/pkg/config/broker"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
 	"knative.dev/pkg/apis"
 )
 
 // Validate the Trigger.
 func (t *Trigger) Validate(ctx context.Context) *apis.FieldError {
+	ctx = apis.WithinParent(ctx, t.ObjectMeta)
 	errs := t.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec")
 	return errs
 }
 
 // Validate the TriggerSpec.
 func (ts *TriggerSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
-	errs = ts.Broker.Validate(ctx).ViaField("broker")
+	// Brokers at other namespaces are allowed, whether the Trigger's namespace
+	// is accepted is decided by the Broker's triggerNamespaceSelector.
+	errs = ts.Broker.Validate(apis.AllowDifferentNamespace(ctx)).ViaField("broker")
 
 	return errs.Also(
 		broker.ValidateSubscriptionAPIFiltersList(ctx, ts.Filters).ViaField("filters"),
 	).Also(
 		ts.Target.Validate(ctx).ViaField("target"),
 	).Also(
-		ts.Delivery.Validate(ctx).ViaField("delivery"),
+		validateDelivery(ctx, ts.Delivery).ViaField("delivery"),
 	)
 }
+
+func validateDelivery(ctx context.Context, ds *eventingduckv1.DeliverySpec) *apis.FieldError {
+	// Knative's delivery spec validation does not know about the
+	// constant backoff policy, skip it when validating the rest of the spec.
+	if ds != nil && ds.BackoffPolicy != nil && *ds.BackoffPolicy == BackoffPolicyConstant {
+		ds = ds.DeepCopy()
+		ds.BackoffPolicy = nil
+	}
+
+	return ds.Validate(ctx)
+}
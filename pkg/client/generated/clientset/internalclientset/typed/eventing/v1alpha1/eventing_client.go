@@ -14,6 +14,8 @@ import (
 
 type EventingV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	BrokersGetter
+	KafkaBrokersGetter
 	MemoryBrokersGetter
 	RedisBrokersGetter
 	TriggersGetter
@@ -24,6 +26,14 @@ type EventingV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *EventingV1alpha1Client) Brokers(namespace string) BrokerInterface {
+	return newBrokers(c, namespace)
+}
+
+func (c *EventingV1alpha1Client) KafkaBrokers(namespace string) KafkaBrokerInterface {
+	return newKafkaBrokers(c, namespace)
+}
+
 func (c *EventingV1alpha1Client) MemoryBrokers(namespace string) MemoryBrokerInterface {
 	return newMemoryBrokers(c, namespace)
 }
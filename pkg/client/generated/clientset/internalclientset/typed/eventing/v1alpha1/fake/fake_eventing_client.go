@@ -14,6 +14,14 @@ type FakeEventingV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeEventingV1alpha1) Brokers(namespace string) v1alpha1.BrokerInterface {
+	return &FakeBrokers{c, namespace}
+}
+
+func (c *FakeEventingV1alpha1) KafkaBrokers(namespace string) v1alpha1.KafkaBrokerInterface {
+	return &FakeKafkaBrokers{c, namespace}
+}
+
 func (c *FakeEventingV1alpha1) MemoryBrokers(namespace string) v1alpha1.MemoryBrokerInterface {
 	return &FakeMemoryBrokers{c, namespace}
 }
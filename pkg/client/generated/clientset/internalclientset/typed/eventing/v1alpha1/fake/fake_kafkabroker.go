@@ -0,0 +1,128 @@
+// Copyright 2022 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeKafkaBrokers implements KafkaBrokerInterface
+type FakeKafkaBrokers struct {
+	Fake *FakeEventingV1alpha1
+	ns   string
+}
+
+var kafkabrokersResource = schema.GroupVersionResource{Group: "eventing.triggermesh.io", Version: "v1alpha1", Resource: "kafkabrokers"}
+
+var kafkabrokersKind = schema.GroupVersionKind{Group: "eventing.triggermesh.io", Version: "v1alpha1", Kind: "KafkaBroker"}
+
+// Get takes name of the kafkaBroker, and returns the corresponding kafkaBroker object, and an error if there is any.
+func (c *FakeKafkaBrokers) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.KafkaBroker, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(kafkabrokersResource, c.ns, name), &v1alpha1.KafkaBroker{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.KafkaBroker), err
+}
+
+// List takes label and field selectors, and returns the list of KafkaBrokers that match those selectors.
+func (c *FakeKafkaBrokers) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.KafkaBrokerList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(kafkabrokersResource, kafkabrokersKind, c.ns, opts), &v1alpha1.KafkaBrokerList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.KafkaBrokerList{ListMeta: obj.(*v1alpha1.KafkaBrokerList).ListMeta}
+	for _, item := range obj.(*v1alpha1.KafkaBrokerList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested kafkaBrokers.
+func (c *FakeKafkaBrokers) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(kafkabrokersResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a kafkaBroker and creates it.  Returns the server's representation of the kafkaBroker, and an error, if there is any.
+func (c *FakeKafkaBrokers) Create(ctx context.Context, kafkaBroker *v1alpha1.KafkaBroker, opts v1.CreateOptions) (result *v1alpha1.KafkaBroker, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(kafkabrokersResource, c.ns, kafkaBroker), &v1alpha1.KafkaBroker{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.KafkaBroker), err
+}
+
+// Update takes the representation of a kafkaBroker and updates it. Returns the server's representation of the kafkaBroker, and an error, if there is any.
+func (c *FakeKafkaBrokers) Update(ctx context.Context, kafkaBroker *v1alpha1.KafkaBroker, opts v1.UpdateOptions) (result *v1alpha1.KafkaBroker, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(kafkabrokersResource, c.ns, kafkaBroker), &v1alpha1.KafkaBroker{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.KafkaBroker), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeKafkaBrokers) UpdateStatus(ctx context.Context, kafkaBroker *v1alpha1.KafkaBroker, opts v1.UpdateOptions) (*v1alpha1.KafkaBroker, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(kafkabrokersResource, "status", c.ns, kafkaBroker), &v1alpha1.KafkaBroker{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.KafkaBroker), err
+}
+
+// Delete takes name of the kafkaBroker and deletes it. Returns an error if one occurs.
+func (c *FakeKafkaBrokers) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(kafkabrokersResource, c.ns, name, opts), &v1alpha1.KafkaBroker{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeKafkaBrokers) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(kafkabrokersResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.KafkaBrokerList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched kafkaBroker.
+func (c *FakeKafkaBrokers) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.KafkaBroker, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(kafkabrokersResource, c.ns, name, pt, data, subresources...), &v1alpha1.KafkaBroker{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.KafkaBroker), err
+}
@@ -4,6 +4,10 @@
 
 package v1alpha1
 
+type BrokerExpansion interface{}
+
+type KafkaBrokerExpansion interface{}
+
 type MemoryBrokerExpansion interface{}
 
 type RedisBrokerExpansion interface{}
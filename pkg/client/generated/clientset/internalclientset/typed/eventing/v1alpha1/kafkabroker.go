@@ -0,0 +1,181 @@
+// Copyright 2022 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	scheme "github.com/triggermesh/triggermesh-core/pkg/client/generated/clientset/internalclientset/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// KafkaBrokersGetter has a method to return a KafkaBrokerInterface.
+// A group's client should implement this interface.
+type KafkaBrokersGetter interface {
+	KafkaBrokers(namespace string) KafkaBrokerInterface
+}
+
+// KafkaBrokerInterface has methods to work with KafkaBroker resources.
+type KafkaBrokerInterface interface {
+	Create(ctx context.Context, kafkaBroker *v1alpha1.KafkaBroker, opts v1.CreateOptions) (*v1alpha1.KafkaBroker, error)
+	Update(ctx context.Context, kafkaBroker *v1alpha1.KafkaBroker, opts v1.UpdateOptions) (*v1alpha1.KafkaBroker, error)
+	UpdateStatus(ctx context.Context, kafkaBroker *v1alpha1.KafkaBroker, opts v1.UpdateOptions) (*v1alpha1.KafkaBroker, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.KafkaBroker, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.KafkaBrokerList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.KafkaBroker, err error)
+	KafkaBrokerExpansion
+}
+
+// kafkaBrokers implements KafkaBrokerInterface
+type kafkaBrokers struct {
+	client rest.Interface
+	ns     string
+}
+
+// newKafkaBrokers returns a KafkaBrokers
+func newKafkaBrokers(c *EventingV1alpha1Client, namespace string) *kafkaBrokers {
+	return &kafkaBrokers{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the kafkaBroker, and returns the corresponding kafkaBroker object, and an error if there is any.
+func (c *kafkaBrokers) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.KafkaBroker, err error) {
+	result = &v1alpha1.KafkaBroker{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("kafkabrokers").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of KafkaBrokers that match those selectors.
+func (c *kafkaBrokers) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.KafkaBrokerList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.KafkaBrokerList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("kafkabrokers").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested kafkaBrokers.
+func (c *kafkaBrokers) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("kafkabrokers").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a kafkaBroker and creates it.  Returns the server's representation of the kafkaBroker, and an error, if there is any.
+func (c *kafkaBrokers) Create(ctx context.Context, kafkaBroker *v1alpha1.KafkaBroker, opts v1.CreateOptions) (result *v1alpha1.KafkaBroker, err error) {
+	result = &v1alpha1.KafkaBroker{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("kafkabrokers").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(kafkaBroker).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a kafkaBroker and updates it. Returns the server's representation of the kafkaBroker, and an error, if there is any.
+func (c *kafkaBrokers) Update(ctx context.Context, kafkaBroker *v1alpha1.KafkaBroker, opts v1.UpdateOptions) (result *v1alpha1.KafkaBroker, err error) {
+	result = &v1alpha1.KafkaBroker{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("kafkabrokers").
+		Name(kafkaBroker.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(kafkaBroker).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *kafkaBrokers) UpdateStatus(ctx context.Context, kafkaBroker *v1alpha1.KafkaBroker, opts v1.UpdateOptions) (result *v1alpha1.KafkaBroker, err error) {
+	result = &v1alpha1.KafkaBroker{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("kafkabrokers").
+		Name(kafkaBroker.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(kafkaBroker).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the kafkaBroker and deletes it. Returns an error if one occurs.
+func (c *kafkaBrokers) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("kafkabrokers").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *kafkaBrokers) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("kafkabrokers").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched kafkaBroker.
+func (c *kafkaBrokers) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.KafkaBroker, err error) {
+	result = &v1alpha1.KafkaBroker{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("kafkabrokers").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
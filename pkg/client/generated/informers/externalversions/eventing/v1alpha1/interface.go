@@ -10,6 +10,10 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// Brokers returns a BrokerInformer.
+	Brokers() BrokerInformer
+	// KafkaBrokers returns a KafkaBrokerInformer.
+	KafkaBrokers() KafkaBrokerInformer
 	// MemoryBrokers returns a MemoryBrokerInformer.
 	MemoryBrokers() MemoryBrokerInformer
 	// RedisBrokers returns a RedisBrokerInformer.
@@ -29,6 +33,16 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// Brokers returns a BrokerInformer.
+func (v *version) Brokers() BrokerInformer {
+	return &brokerInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// KafkaBrokers returns a KafkaBrokerInformer.
+func (v *version) KafkaBrokers() KafkaBrokerInformer {
+	return &kafkaBrokerInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // MemoryBrokers returns a MemoryBrokerInformer.
 func (v *version) MemoryBrokers() MemoryBrokerInformer {
 	return &memoryBrokerInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -0,0 +1,76 @@
+// Copyright 2022 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	time "time"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	internalclientset "github.com/triggermesh/triggermesh-core/pkg/client/generated/clientset/internalclientset"
+	internalinterfaces "github.com/triggermesh/triggermesh-core/pkg/client/generated/informers/externalversions/internalinterfaces"
+	v1alpha1 "github.com/triggermesh/triggermesh-core/pkg/client/generated/listers/eventing/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// KafkaBrokerInformer provides access to a shared informer and lister for
+// KafkaBrokers.
+type KafkaBrokerInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.KafkaBrokerLister
+}
+
+type kafkaBrokerInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewKafkaBrokerInformer constructs a new informer for KafkaBroker type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewKafkaBrokerInformer(client internalclientset.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredKafkaBrokerInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredKafkaBrokerInformer constructs a new informer for KafkaBroker type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredKafkaBrokerInformer(client internalclientset.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.EventingV1alpha1().KafkaBrokers(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.EventingV1alpha1().KafkaBrokers(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&eventingv1alpha1.KafkaBroker{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *kafkaBrokerInformer) defaultInformer(client internalclientset.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredKafkaBrokerInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *kafkaBrokerInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&eventingv1alpha1.KafkaBroker{}, f.defaultInformer)
+}
+
+func (f *kafkaBrokerInformer) Lister() v1alpha1.KafkaBrokerLister {
+	return v1alpha1.NewKafkaBrokerLister(f.Informer().GetIndexer())
+}
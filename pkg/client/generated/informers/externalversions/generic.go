@@ -39,6 +39,10 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=eventing.triggermesh.io, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithResource("brokers"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Eventing().V1alpha1().Brokers().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("kafkabrokers"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Eventing().V1alpha1().KafkaBrokers().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("memorybrokers"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Eventing().V1alpha1().MemoryBrokers().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("redisbrokers"):
@@ -0,0 +1,26 @@
+// Copyright 2022 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+// Code generated by injection-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	context "context"
+
+	trigger "github.com/triggermesh/triggermesh-core/pkg/client/generated/knative/injection/informers/eventing/v1/trigger"
+	fake "github.com/triggermesh/triggermesh-core/pkg/client/generated/knative/injection/informers/factory/fake"
+	controller "knative.dev/pkg/controller"
+	injection "knative.dev/pkg/injection"
+)
+
+var Get = trigger.Get
+
+func init() {
+	injection.Fake.RegisterInformer(withInformer)
+}
+
+func withInformer(ctx context.Context) (context.Context, controller.Informer) {
+	f := fake.Get(ctx)
+	inf := f.Eventing().V1().Triggers()
+	return context.WithValue(ctx, trigger.Key{}, inf), inf.Informer()
+}
@@ -4,6 +4,22 @@
 
 package v1alpha1
 
+// BrokerListerExpansion allows custom methods to be added to
+// BrokerLister.
+type BrokerListerExpansion interface{}
+
+// BrokerNamespaceListerExpansion allows custom methods to be added to
+// BrokerNamespaceLister.
+type BrokerNamespaceListerExpansion interface{}
+
+// KafkaBrokerListerExpansion allows custom methods to be added to
+// KafkaBrokerLister.
+type KafkaBrokerListerExpansion interface{}
+
+// KafkaBrokerNamespaceListerExpansion allows custom methods to be added to
+// KafkaBrokerNamespaceLister.
+type KafkaBrokerNamespaceListerExpansion interface{}
+
 // MemoryBrokerListerExpansion allows custom methods to be added to
 // MemoryBrokerLister.
 type MemoryBrokerListerExpansion interface{}
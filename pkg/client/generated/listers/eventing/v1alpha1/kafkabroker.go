@@ -0,0 +1,85 @@
+// Copyright 2022 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// KafkaBrokerLister helps list KafkaBrokers.
+// All objects returned here must be treated as read-only.
+type KafkaBrokerLister interface {
+	// List lists all KafkaBrokers in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.KafkaBroker, err error)
+	// KafkaBrokers returns an object that can list and get KafkaBrokers.
+	KafkaBrokers(namespace string) KafkaBrokerNamespaceLister
+	KafkaBrokerListerExpansion
+}
+
+// kafkaBrokerLister implements the KafkaBrokerLister interface.
+type kafkaBrokerLister struct {
+	indexer cache.Indexer
+}
+
+// NewKafkaBrokerLister returns a new KafkaBrokerLister.
+func NewKafkaBrokerLister(indexer cache.Indexer) KafkaBrokerLister {
+	return &kafkaBrokerLister{indexer: indexer}
+}
+
+// List lists all KafkaBrokers in the indexer.
+func (s *kafkaBrokerLister) List(selector labels.Selector) (ret []*v1alpha1.KafkaBroker, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.KafkaBroker))
+	})
+	return ret, err
+}
+
+// KafkaBrokers returns an object that can list and get KafkaBrokers.
+func (s *kafkaBrokerLister) KafkaBrokers(namespace string) KafkaBrokerNamespaceLister {
+	return kafkaBrokerNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// KafkaBrokerNamespaceLister helps list and get KafkaBrokers.
+// All objects returned here must be treated as read-only.
+type KafkaBrokerNamespaceLister interface {
+	// List lists all KafkaBrokers in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.KafkaBroker, err error)
+	// Get retrieves the KafkaBroker from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.KafkaBroker, error)
+	KafkaBrokerNamespaceListerExpansion
+}
+
+// kafkaBrokerNamespaceLister implements the KafkaBrokerNamespaceLister
+// interface.
+type kafkaBrokerNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all KafkaBrokers in the indexer for a given namespace.
+func (s kafkaBrokerNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.KafkaBroker, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.KafkaBroker))
+	})
+	return ret, err
+}
+
+// Get retrieves the KafkaBroker from the indexer for a given namespace and name.
+func (s kafkaBrokerNamespaceLister) Get(name string) (*v1alpha1.KafkaBroker, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("kafkabroker"), name)
+	}
+	return obj.(*v1alpha1.KafkaBroker), nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package broker
+
+import (
+	"context"
+
+	"k8s.io/client-go/tools/cache"
+
+	cmw "knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+
+	"github.com/triggermesh/triggermesh-core/pkg/apis/config"
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	eventingclient "github.com/triggermesh/triggermesh-core/pkg/client/generated/injection/client"
+	brinformer "github.com/triggermesh/triggermesh-core/pkg/client/generated/injection/informers/eventing/v1alpha1/broker"
+	brreconciler "github.com/triggermesh/triggermesh-core/pkg/client/generated/injection/reconciler/eventing/v1alpha1/broker"
+	"github.com/triggermesh/triggermesh-core/pkg/reconciler/common"
+)
+
+// NewController initializes the controller and is called by the generated code
+// Registers event handlers to enqueue events
+func NewController(
+	ctx context.Context,
+	cmw cmw.Watcher,
+) *controller.Impl {
+	brInformer := brinformer.Get(ctx)
+
+	r := &reconciler{
+		brokerResolver: common.NewBrokerResolver(ctx),
+		classes:        newBackingBrokerClasses(eventingclient.Get(ctx)),
+	}
+
+	// The cluster wide default class is used for Brokers that were not
+	// defaulted by the webhook.
+	store := config.NewStore(logging.FromContext(ctx).Named("config-store"))
+	store.WatchConfigs(cmw)
+
+	impl := brreconciler.NewImpl(ctx, r, func(impl *controller.Impl) controller.Options {
+		return controller.Options{
+			ConfigStore: store,
+		}
+	})
+
+	brInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+
+	// Brokers of each class are watched to propagate their status.
+	r.brokerResolver.AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterController(&eventingv1alpha1.Broker{}),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	return impl
+}
@@ -0,0 +1,267 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package broker
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	"github.com/triggermesh/triggermesh-core/pkg/client/generated/clientset/internalclientset"
+	"github.com/triggermesh/triggermesh-core/pkg/reconciler/common"
+)
+
+// backingBrokerClass manages the brokers of the kind that serves a Broker
+// class. Existing brokers are retrieved using the broker resolver.
+type backingBrokerClass struct {
+	// desired returns the defaulted broker that serves the Broker.
+	desired func(ctx context.Context, b *eventingv1alpha1.Broker) eventingv1alpha1.ReconcilableBroker
+	// withSpec returns a copy of the current broker using the desired spec.
+	withSpec func(current, desired eventingv1alpha1.ReconcilableBroker) eventingv1alpha1.ReconcilableBroker
+
+	create func(ctx context.Context, tb eventingv1alpha1.ReconcilableBroker) (eventingv1alpha1.ReconcilableBroker, error)
+	update func(ctx context.Context, tb eventingv1alpha1.ReconcilableBroker) (eventingv1alpha1.ReconcilableBroker, error)
+	delete func(ctx context.Context, namespace, name string) error
+}
+
+// newBackingBrokerClasses returns the operations for all classes that can
+// serve a Broker. New broker classes need to be registered here.
+func newBackingBrokerClasses(client internalclientset.Interface) map[eventingv1alpha1.BrokerClass]backingBrokerClass {
+	c := client.EventingV1alpha1()
+
+	return map[eventingv1alpha1.BrokerClass]backingBrokerClass{
+		eventingv1alpha1.BrokerClassMemory: {
+			desired: func(ctx context.Context, b *eventingv1alpha1.Broker) eventingv1alpha1.ReconcilableBroker {
+				mb := &eventingv1alpha1.MemoryBroker{
+					ObjectMeta: newBackingBrokerMeta(b),
+					Spec:       *b.Spec.DeepCopy().MemoryBrokerSpec(),
+				}
+				mb.SetDefaults(ctx)
+				return mb
+			},
+			withSpec: func(current, desired eventingv1alpha1.ReconcilableBroker) eventingv1alpha1.ReconcilableBroker {
+				mb := current.(*eventingv1alpha1.MemoryBroker).DeepCopy()
+				mb.Spec = desired.(*eventingv1alpha1.MemoryBroker).Spec
+				return mb
+			},
+			create: func(ctx context.Context, tb eventingv1alpha1.ReconcilableBroker) (eventingv1alpha1.ReconcilableBroker, error) {
+				mb := tb.(*eventingv1alpha1.MemoryBroker)
+				return reconcilableBroker(c.MemoryBrokers(mb.Namespace).Create(ctx, mb, metav1.CreateOptions{}))
+			},
+			update: func(ctx context.Context, tb eventingv1alpha1.ReconcilableBroker) (eventingv1alpha1.ReconcilableBroker, error) {
+				mb := tb.(*eventingv1alpha1.MemoryBroker)
+				return reconcilableBroker(c.MemoryBrokers(mb.Namespace).Update(ctx, mb, metav1.UpdateOptions{}))
+			},
+			delete: func(ctx context.Context, namespace, name string) error {
+				return c.MemoryBrokers(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+			},
+		},
+
+		eventingv1alpha1.BrokerClassRedis: {
+			desired: func(ctx context.Context, b *eventingv1alpha1.Broker) eventingv1alpha1.ReconcilableBroker {
+				rb := &eventingv1alpha1.RedisBroker{
+					ObjectMeta: newBackingBrokerMeta(b),
+					Spec:       *b.Spec.DeepCopy().RedisBrokerSpec(),
+				}
+				rb.SetDefaults(ctx)
+				return rb
+			},
+			withSpec: func(current, desired eventingv1alpha1.ReconcilableBroker) eventingv1alpha1.ReconcilableBroker {
+				rb := current.(*eventingv1alpha1.RedisBroker).DeepCopy()
+				rb.Spec = desired.(*eventingv1alpha1.RedisBroker).Spec
+				return rb
+			},
+			create: func(ctx context.Context, tb eventingv1alpha1.ReconcilableBroker) (eventingv1alpha1.ReconcilableBroker, error) {
+				rb := tb.(*eventingv1alpha1.RedisBroker)
+				return reconcilableBroker(c.RedisBrokers(rb.Namespace).Create(ctx, rb, metav1.CreateOptions{}))
+			},
+			update: func(ctx context.Context, tb eventingv1alpha1.ReconcilableBroker) (eventingv1alpha1.ReconcilableBroker, error) {
+				rb := tb.(*eventingv1alpha1.RedisBroker)
+				return reconcilableBroker(c.RedisBrokers(rb.Namespace).Update(ctx, rb, metav1.UpdateOptions{}))
+			},
+			delete: func(ctx context.Context, namespace, name string) error {
+				return c.RedisBrokers(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+			},
+		},
+
+		eventingv1alpha1.BrokerClassKafka: {
+			desired: func(ctx context.Context, b *eventingv1alpha1.Broker) eventingv1alpha1.ReconcilableBroker {
+				kb := &eventingv1alpha1.KafkaBroker{
+					ObjectMeta: newBackingBrokerMeta(b),
+					Spec:       *b.Spec.DeepCopy().KafkaBrokerSpec(),
+				}
+				kb.SetDefaults(ctx)
+				return kb
+			},
+			withSpec: func(current, desired eventingv1alpha1.ReconcilableBroker) eventingv1alpha1.ReconcilableBroker {
+				kb := current.(*eventingv1alpha1.KafkaBroker).DeepCopy()
+				kb.Spec = desired.(*eventingv1alpha1.KafkaBroker).Spec
+				return kb
+			},
+			create: func(ctx context.Context, tb eventingv1alpha1.ReconcilableBroker) (eventingv1alpha1.ReconcilableBroker, error) {
+				kb := tb.(*eventingv1alpha1.KafkaBroker)
+				return reconcilableBroker(c.KafkaBrokers(kb.Namespace).Create(ctx, kb, metav1.CreateOptions{}))
+			},
+			update: func(ctx context.Context, tb eventingv1alpha1.ReconcilableBroker) (eventingv1alpha1.ReconcilableBroker, error) {
+				kb := tb.(*eventingv1alpha1.KafkaBroker)
+				return reconcilableBroker(c.KafkaBrokers(kb.Namespace).Update(ctx, kb, metav1.UpdateOptions{}))
+			},
+			delete: func(ctx context.Context, namespace, name string) error {
+				return c.KafkaBrokers(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+			},
+		},
+	}
+}
+
+// reconcilableBroker wraps the result of a typed client call, making sure that
+// typed nil pointers are not returned as interfaces.
+func reconcilableBroker(tb eventingv1alpha1.ReconcilableBroker, err error) (eventingv1alpha1.ReconcilableBroker, error) {
+	if err != nil {
+		return nil, err
+	}
+	return tb, nil
+}
+
+type reconciler struct {
+	brokerResolver common.BrokerResolver
+	classes        map[eventingv1alpha1.BrokerClass]backingBrokerClass
+}
+
+func (r *reconciler) ReconcileKind(ctx context.Context, b *eventingv1alpha1.Broker) pkgreconciler.Event {
+	logging.FromContext(ctx).Infow("Reconciling", zap.Any("Broker", *b))
+
+	// Brokers created before the webhook was deployed might not be defaulted.
+	b.SetDefaults(ctx)
+
+	class, ok := r.classes[b.Spec.Class]
+	if !ok {
+		// Unexpected path, the class is validated by the webhook.
+		b.Status.MarkBackingBrokerFailed("UnknownClass", "Broker class %q is not supported", b.Spec.Class)
+		return controller.NewPermanentError(fmt.Errorf("not supported Broker class %q", b.Spec.Class))
+	}
+
+	tb, err := r.reconcileBackingBroker(ctx, b, class)
+	if err != nil {
+		return err
+	}
+
+	// When the class changes, the broker that served the previous class
+	// is removed.
+	if err := r.deleteStaleBackingBrokers(ctx, b); err != nil {
+		return err
+	}
+
+	b.Status.PropagateBackingBroker(tb)
+
+	return nil
+}
+
+// reconcileBackingBroker creates or updates the broker of the Broker's class.
+func (r *reconciler) reconcileBackingBroker(ctx context.Context, b *eventingv1alpha1.Broker, class backingBrokerClass) (eventingv1alpha1.ReconcilableBroker, pkgreconciler.Event) {
+	kind := string(b.Spec.Class)
+
+	desired := class.desired(ctx, b)
+
+	current, err := r.brokerResolver.Resolve(eventingv1alpha1.Kind(kind), b.Namespace, b.Name)
+	switch {
+	case apierrs.IsNotFound(err):
+		tb, err := class.create(ctx, desired)
+		if err != nil {
+			b.Status.MarkBackingBrokerFailed(common.ReasonFailedBrokerCreate, "Failed to create %s: %s", kind, err)
+			return nil, pkgreconciler.NewEvent(corev1.EventTypeWarning, common.ReasonFailedBrokerCreate,
+				"Failed to create %s %s/%s: %w", kind, b.Namespace, b.Name, err)
+		}
+		return tb, nil
+
+	case err != nil:
+		b.Status.MarkBackingBrokerFailed(common.ReasonFailedBrokerGet, "Failed to get %s: %s", kind, err)
+		return nil, pkgreconciler.NewEvent(corev1.EventTypeWarning, common.ReasonFailedBrokerGet,
+			"Failed to get %s %s/%s: %w", kind, b.Namespace, b.Name, err)
+	}
+
+	if err := checkBackingBrokerOwner(b, current); err != nil {
+		return nil, err
+	}
+
+	updated := class.withSpec(current, desired)
+	if equality.Semantic.DeepEqual(updated, current) {
+		return current, nil
+	}
+
+	tb, err := class.update(ctx, updated)
+	if err != nil {
+		b.Status.MarkBackingBrokerFailed(common.ReasonFailedBrokerUpdate, "Failed to update %s: %s", kind, err)
+		return nil, pkgreconciler.NewEvent(corev1.EventTypeWarning, common.ReasonFailedBrokerUpdate,
+			"Failed to update %s %s/%s: %w", kind, b.Namespace, b.Name, err)
+	}
+
+	return tb, nil
+}
+
+// deleteStaleBackingBrokers removes the brokers controlled by the Broker that
+// do not match its current class.
+func (r *reconciler) deleteStaleBackingBrokers(ctx context.Context, b *eventingv1alpha1.Broker) pkgreconciler.Event {
+	for class, bc := range r.classes {
+		if class == b.Spec.Class {
+			continue
+		}
+
+		tb, err := r.brokerResolver.Resolve(eventingv1alpha1.Kind(string(class)), b.Namespace, b.Name)
+		switch {
+		case apierrs.IsNotFound(err):
+			continue
+		case err != nil:
+			return pkgreconciler.NewEvent(corev1.EventTypeWarning, common.ReasonFailedBrokerGet,
+				"Failed to get %s %s/%s: %w", class, b.Namespace, b.Name, err)
+		}
+
+		if !metav1.IsControlledBy(tb.GetObjectMeta(), b) {
+			continue
+		}
+
+		if err := bc.delete(ctx, b.Namespace, b.Name); err != nil && !apierrs.IsNotFound(err) {
+			return pkgreconciler.NewEvent(corev1.EventTypeWarning, common.ReasonFailedBrokerDelete,
+				"Failed to delete %s %s/%s: %w", class, b.Namespace, b.Name, err)
+		}
+
+		logging.FromContext(ctx).Infow("Deleted broker of previous class",
+			zap.String("class", string(class)), zap.String("name", b.Name))
+	}
+
+	return nil
+}
+
+// newBackingBrokerMeta returns the metadata for the broker that serves the
+// Broker, which uses the Broker's name and is controlled by it.
+func newBackingBrokerMeta(b *eventingv1alpha1.Broker) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace:       b.Namespace,
+		Name:            b.Name,
+		OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(b)},
+	}
+}
+
+// checkBackingBrokerOwner makes sure that an existing broker is controlled by
+// the Broker before taking it over.
+func checkBackingBrokerOwner(b *eventingv1alpha1.Broker, tb eventingv1alpha1.ReconcilableBroker) pkgreconciler.Event {
+	if metav1.IsControlledBy(tb.GetObjectMeta(), b) {
+		return nil
+	}
+
+	kind := tb.GetGroupVersionKind().Kind
+	b.Status.MarkBackingBrokerFailed(common.ReasonBrokerNotOwned, "%s %q is not owned by the Broker", kind, b.Name)
+	// No need to requeue, we will be notified when the broker changes.
+	return controller.NewPermanentError(fmt.Errorf("%s %s/%s is not owned by the Broker", kind, b.Namespace, b.Name))
+}
@@ -0,0 +1,158 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package broker
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/controller"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	fakeclientset "github.com/triggermesh/triggermesh-core/pkg/client/generated/clientset/internalclientset/fake"
+	"github.com/triggermesh/triggermesh-core/pkg/reconciler/resources"
+	tmt "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing"
+	tresources "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing/resources"
+	tmtv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing/v1alpha1"
+)
+
+func TestReconcileKind(t *testing.T) {
+	newBroker := func(class eventingv1alpha1.BrokerClass) *eventingv1alpha1.Broker {
+		return tmtv1alpha1.NewBroker(tresources.TestNamespace, tresources.TestName,
+			tmtv1alpha1.BrokerWithClass(class))
+	}
+
+	b := newBroker(eventingv1alpha1.BrokerClassMemory)
+	ownedByBroker := resources.MetaAddOwner(b, b.GetGroupVersionKind())
+
+	testCases := map[string]struct {
+		broker  *eventingv1alpha1.Broker
+		objects []runtime.Object
+
+		expectPermanentErr bool
+		expectMemoryBroker bool
+		expectRedisBroker  bool
+		expectReady        corev1.ConditionStatus
+	}{
+		"create backing broker": {
+			broker:             newBroker(eventingv1alpha1.BrokerClassMemory),
+			expectMemoryBroker: true,
+			expectReady:        corev1.ConditionUnknown,
+		},
+		"existing backing broker": {
+			broker: newBroker(eventingv1alpha1.BrokerClassMemory),
+			objects: []runtime.Object{
+				tmtv1alpha1.NewMemoryBroker(tresources.TestNamespace, tresources.TestName,
+					tmtv1alpha1.MemoryBrokerWithMetaOptions(ownedByBroker),
+					tmtv1alpha1.MemoryBrokerWithStatusAddress("http://test-name-mb-broker.test-namespace.svc.cluster.local"),
+					tmtv1alpha1.MemoryBrokerWithStatusCondition(string(eventingv1alpha1.MemoryBrokerConditionReady), corev1.ConditionTrue, "", "")),
+			},
+			expectMemoryBroker: true,
+			expectReady:        corev1.ConditionTrue,
+		},
+		"backing broker not owned": {
+			broker: newBroker(eventingv1alpha1.BrokerClassMemory),
+			objects: []runtime.Object{
+				tmtv1alpha1.NewMemoryBroker(tresources.TestNamespace, tresources.TestName),
+			},
+			expectPermanentErr: true,
+			expectMemoryBroker: true,
+			expectReady:        corev1.ConditionFalse,
+		},
+		"class changed": {
+			broker: newBroker(eventingv1alpha1.BrokerClassMemory),
+			objects: []runtime.Object{
+				tmtv1alpha1.NewRedisBroker(tresources.TestNamespace, tresources.TestName,
+					tmtv1alpha1.RedisBrokerWithMetaOptions(ownedByBroker)),
+			},
+			expectMemoryBroker: true,
+			expectReady:        corev1.ConditionUnknown,
+		},
+		"class changed keeps not owned brokers": {
+			broker: newBroker(eventingv1alpha1.BrokerClassMemory),
+			objects: []runtime.Object{
+				tmtv1alpha1.NewRedisBroker(tresources.TestNamespace, tresources.TestName),
+			},
+			expectMemoryBroker: true,
+			expectRedisBroker:  true,
+			expectReady:        corev1.ConditionUnknown,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			ls := tmt.NewListers(tc.objects)
+			client := fakeclientset.NewSimpleClientset(tc.objects...)
+
+			r := &reconciler{
+				brokerResolver: &listerBrokerResolver{ls: ls},
+				classes:        newBackingBrokerClasses(client),
+			}
+
+			err := r.ReconcileKind(ctx, tc.broker)
+			if tc.expectPermanentErr {
+				assert.True(t, controller.IsPermanentError(err), "expected permanent error, got %v", err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			_, err = client.EventingV1alpha1().MemoryBrokers(tresources.TestNamespace).Get(ctx, tresources.TestName, metav1.GetOptions{})
+			assert.Equal(t, tc.expectMemoryBroker, err == nil, "unexpected MemoryBroker get result: %v", err)
+
+			_, err = client.EventingV1alpha1().RedisBrokers(tresources.TestNamespace).Get(ctx, tresources.TestName, metav1.GetOptions{})
+			if tc.expectRedisBroker {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, apierrs.IsNotFound(err), "expected RedisBroker not to exist, got %v", err)
+			}
+
+			c := tc.broker.Status.GetCondition(eventingv1alpha1.BrokerConditionBackingBroker)
+			require.NotNil(t, c)
+			assert.Equal(t, tc.expectReady, c.Status)
+		})
+	}
+}
+
+// listerBrokerResolver resolves brokers from the test listers.
+type listerBrokerResolver struct {
+	ls tmt.Listers
+}
+
+func (r *listerBrokerResolver) Resolve(gk schema.GroupKind, namespace, name string) (eventingv1alpha1.ReconcilableBroker, error) {
+	var tb eventingv1alpha1.ReconcilableBroker
+	var err error
+
+	switch gk.Kind {
+	case string(eventingv1alpha1.BrokerClassMemory):
+		tb, err = r.ls.GetMemoryBrokerLister().MemoryBrokers(namespace).Get(name)
+	case string(eventingv1alpha1.BrokerClassRedis):
+		tb, err = r.ls.GetRedisBrokerLister().RedisBrokers(namespace).Get(name)
+	case string(eventingv1alpha1.BrokerClassKafka):
+		tb, err = r.ls.GetKafkaBrokerLister().KafkaBrokers(namespace).Get(name)
+	default:
+		return nil, fmt.Errorf("not supported Broker %q", gk)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return tb, nil
+}
+
+func (r *listerBrokerResolver) IsRegistered(gk schema.GroupKind) bool {
+	return true
+}
+
+func (r *listerBrokerResolver) AddEventHandler(handler cache.ResourceEventHandler) {}
@@ -0,0 +1,50 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/network"
+	"knative.dev/pkg/ptr"
+)
+
+const brokerHTTPPortName = "httpce"
+
+// BrokerAddresses returns the HTTP address of the broker Service along with
+// all addresses it can be reached at.
+func BrokerAddresses(svc *corev1.Service) (*apis.URL, []duckv1.Addressable) {
+	if svc == nil {
+		return nil, nil
+	}
+
+	host := network.GetServiceHostname(svc.Name, svc.Namespace)
+
+	var http *apis.URL
+	var addresses []duckv1.Addressable
+	for _, p := range svc.Spec.Ports {
+		if p.Name != brokerHTTPPortName {
+			continue
+		}
+
+		http = apis.HTTP(hostPort(host, p.Port, defaultBrokerServicePort))
+		addresses = append(addresses, duckv1.Addressable{
+			Name: ptr.String("http"),
+			URL:  http,
+		})
+	}
+
+	return http, addresses
+}
+
+// hostPort omits the port when it is the scheme's default.
+func hostPort(host string, port, defaultPort int32) string {
+	if port == defaultPort {
+		return host
+	}
+	return host + ":" + strconv.Itoa(int(port))
+}
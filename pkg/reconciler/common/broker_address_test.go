@@ -0,0 +1,51 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	tresources "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing/resources"
+	tmtv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing/v1alpha1"
+)
+
+func TestBrokerAddresses(t *testing.T) {
+	host := tresources.TestName + "-mb-broker." + tresources.TestNamespace + ".svc.cluster.local"
+
+	testCases := map[string]struct {
+		spec        eventingv1alpha1.CommonBrokerSpec
+		expectedURL string
+	}{
+		"default port": {
+			expectedURL: "http://" + host,
+		},
+		"custom port": {
+			spec:        eventingv1alpha1.CommonBrokerSpec{Port: intPtr(8080)},
+			expectedURL: "http://" + host + ":8080",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			b := tmtv1alpha1.NewMemoryBroker(tresources.TestNamespace, tresources.TestName)
+			b.Spec.Broker = tc.spec
+
+			address, addresses := BrokerAddresses(buildBrokerService(b))
+
+			require.NotNil(t, address)
+			assert.Equal(t, tc.expectedURL, address.String())
+			require.Len(t, addresses, 1)
+			assert.Equal(t, "http", *addresses[0].Name)
+			assert.Equal(t, tc.expectedURL, addresses[0].URL.String())
+		})
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
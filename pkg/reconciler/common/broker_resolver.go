@@ -0,0 +1,142 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	brinformer "github.com/triggermesh/triggermesh-core/pkg/client/generated/injection/informers/eventing/v1alpha1/broker"
+	kbinformer "github.com/triggermesh/triggermesh-core/pkg/client/generated/injection/informers/eventing/v1alpha1/kafkabroker"
+	mbinformer "github.com/triggermesh/triggermesh-core/pkg/client/generated/injection/informers/eventing/v1alpha1/memorybroker"
+	rbinformer "github.com/triggermesh/triggermesh-core/pkg/client/generated/injection/informers/eventing/v1alpha1/redisbroker"
+	eventinglisters "github.com/triggermesh/triggermesh-core/pkg/client/generated/listers/eventing/v1alpha1"
+)
+
+// BrokerGetter retrieves a broker of a concrete kind by namespace and name.
+type BrokerGetter func(namespace, name string) (eventingv1alpha1.ReconcilableBroker, error)
+
+// BrokerResolver retrieves brokers of any registered kind using the
+// ReconcilableBroker duck interface.
+type BrokerResolver interface {
+	// Resolve returns the broker for the group kind, namespace and name.
+	Resolve(gk schema.GroupKind, namespace, name string) (eventingv1alpha1.ReconcilableBroker, error)
+	// IsRegistered returns whether the group kind is a known broker kind.
+	IsRegistered(gk schema.GroupKind) bool
+	// AddEventHandler adds the handler to the informers of all registered broker kinds.
+	AddEventHandler(handler cache.ResourceEventHandler)
+}
+
+type brokerKind struct {
+	get      BrokerGetter
+	informer cache.SharedIndexInformer
+}
+
+type brokerResolver struct {
+	kinds map[schema.GroupKind]brokerKind
+}
+
+var _ BrokerResolver = (*brokerResolver)(nil)
+
+// NewBrokerResolver returns a resolver for all broker kinds managed by
+// TriggerMesh core. New broker kinds need to be registered here.
+func NewBrokerResolver(ctx context.Context) BrokerResolver {
+	r := &brokerResolver{
+		kinds: make(map[schema.GroupKind]brokerKind),
+	}
+
+	rbInformer := rbinformer.Get(ctx)
+	r.register((&eventingv1alpha1.RedisBroker{}).GetGroupVersionKind().GroupKind(),
+		func(namespace, name string) (eventingv1alpha1.ReconcilableBroker, error) {
+			b, err := rbInformer.Lister().RedisBrokers(namespace).Get(name)
+			if err != nil {
+				return nil, err
+			}
+			return b, nil
+		}, rbInformer.Informer())
+
+	mbInformer := mbinformer.Get(ctx)
+	r.register((&eventingv1alpha1.MemoryBroker{}).GetGroupVersionKind().GroupKind(),
+		func(namespace, name string) (eventingv1alpha1.ReconcilableBroker, error) {
+			b, err := mbInformer.Lister().MemoryBrokers(namespace).Get(name)
+			if err != nil {
+				return nil, err
+			}
+			return b, nil
+		}, mbInformer.Informer())
+
+	kbInformer := kbinformer.Get(ctx)
+	r.register((&eventingv1alpha1.KafkaBroker{}).GetGroupVersionKind().GroupKind(),
+		func(namespace, name string) (eventingv1alpha1.ReconcilableBroker, error) {
+			b, err := kbInformer.Lister().KafkaBrokers(namespace).Get(name)
+			if err != nil {
+				return nil, err
+			}
+			return b, nil
+		}, kbInformer.Informer())
+
+	brInformer := brinformer.Get(ctx)
+	r.register((&eventingv1alpha1.Broker{}).GetGroupVersionKind().GroupKind(),
+		r.classBrokerGetter(brInformer.Lister()), nil)
+
+	return r
+}
+
+// classBrokerGetter returns a getter for class based Brokers, which are served
+// by a broker of the class kind with the same name that is returned instead.
+// Changes at Brokers are reflected at the serving broker, which is why their
+// informer is not registered.
+func (r *brokerResolver) classBrokerGetter(lister eventinglisters.BrokerLister) BrokerGetter {
+	return func(namespace, name string) (eventingv1alpha1.ReconcilableBroker, error) {
+		b, err := lister.Brokers(namespace).Get(name)
+		if err != nil {
+			return nil, err
+		}
+
+		tb, err := r.Resolve(eventingv1alpha1.Kind(string(b.Spec.Class)), namespace, name)
+		if err != nil {
+			return nil, err
+		}
+
+		if !metav1.IsControlledBy(tb.GetObjectMeta(), b) {
+			return nil, fmt.Errorf("%s %s/%s is not controlled by the Broker", b.Spec.Class, namespace, name)
+		}
+
+		return tb, nil
+	}
+}
+
+func (r *brokerResolver) register(gk schema.GroupKind, get BrokerGetter, informer cache.SharedIndexInformer) {
+	r.kinds[gk] = brokerKind{
+		get:      get,
+		informer: informer,
+	}
+}
+
+func (r *brokerResolver) Resolve(gk schema.GroupKind, namespace, name string) (eventingv1alpha1.ReconcilableBroker, error) {
+	bk, ok := r.kinds[gk]
+	if !ok {
+		return nil, fmt.Errorf("not supported Broker %q", gk)
+	}
+
+	return bk.get(namespace, name)
+}
+
+func (r *brokerResolver) IsRegistered(gk schema.GroupKind) bool {
+	_, ok := r.kinds[gk]
+	return ok
+}
+
+func (r *brokerResolver) AddEventHandler(handler cache.ResourceEventHandler) {
+	for _, bk := range r.kinds {
+		if bk.informer != nil {
+			bk.informer.AddEventHandler(handler)
+		}
+	}
+}
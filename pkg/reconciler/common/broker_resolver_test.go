@@ -0,0 +1,118 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	"github.com/triggermesh/triggermesh-core/pkg/reconciler/resources"
+	tmt "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing"
+	tresources "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing/resources"
+	tmtv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing/v1alpha1"
+)
+
+func TestBrokerResolver(t *testing.T) {
+	served := tmtv1alpha1.NewBroker(tresources.TestNamespace, "served",
+		tmtv1alpha1.BrokerWithClass(eventingv1alpha1.BrokerClassMemory))
+	notServed := tmtv1alpha1.NewBroker(tresources.TestNamespace, tresources.TestName,
+		tmtv1alpha1.BrokerWithClass(eventingv1alpha1.BrokerClassMemory))
+
+	ls := tmt.NewListers([]runtime.Object{
+		tmtv1alpha1.NewMemoryBroker(tresources.TestNamespace, tresources.TestName),
+		tmtv1alpha1.NewMemoryBroker(tresources.TestNamespace, "served",
+			tmtv1alpha1.MemoryBrokerWithMetaOptions(
+				resources.MetaAddOwner(served, served.GetGroupVersionKind()))),
+		served,
+		notServed,
+	})
+
+	r := &brokerResolver{
+		kinds: make(map[schema.GroupKind]brokerKind),
+	}
+
+	mbGK := (&eventingv1alpha1.MemoryBroker{}).GetGroupVersionKind().GroupKind()
+	r.register(mbGK, func(namespace, name string) (eventingv1alpha1.ReconcilableBroker, error) {
+		b, err := ls.GetMemoryBrokerLister().MemoryBrokers(namespace).Get(name)
+		if err != nil {
+			return nil, err
+		}
+		return b, nil
+	}, nil)
+
+	bGK := (&eventingv1alpha1.Broker{}).GetGroupVersionKind().GroupKind()
+	r.register(bGK, r.classBrokerGetter(ls.GetBrokerLister()), nil)
+
+	rbGK := (&eventingv1alpha1.RedisBroker{}).GetGroupVersionKind().GroupKind()
+
+	testCases := map[string]struct {
+		gk         schema.GroupKind
+		name       string
+		registered bool
+		notFound   bool
+		failed     bool
+		expectGK   *schema.GroupKind
+	}{
+		"registered existing broker": {
+			gk:         mbGK,
+			name:       tresources.TestName,
+			registered: true,
+		},
+		"registered non existing broker": {
+			gk:         mbGK,
+			name:       "other",
+			registered: true,
+			notFound:   true,
+		},
+		"class based broker served by its broker": {
+			gk:         bGK,
+			name:       "served",
+			registered: true,
+			expectGK:   &mbGK,
+		},
+		"class based broker not controlling the broker": {
+			gk:         bGK,
+			name:       tresources.TestName,
+			registered: true,
+			failed:     true,
+		},
+		"non registered broker kind": {
+			gk:   rbGK,
+			name: tresources.TestName,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.registered, r.IsRegistered(tc.gk))
+
+			b, err := r.Resolve(tc.gk, tresources.TestNamespace, tc.name)
+			switch {
+			case !tc.registered:
+				assert.Error(t, err)
+			case tc.failed:
+				assert.Error(t, err)
+				assert.False(t, apierrs.IsNotFound(err), "expected non not found error")
+			case tc.notFound:
+				assert.True(t, apierrs.IsNotFound(err), "expected not found error, got %v", err)
+				assert.Nil(t, b)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tc.name, b.GetObjectMeta().GetName())
+				expectGK := tc.gk
+				if tc.expectGK != nil {
+					expectGK = *tc.expectGK
+				}
+				assert.Equal(t, expectGK, b.GetGroupVersionKind().GroupKind())
+			}
+		})
+	}
+}
@@ -11,11 +11,48 @@ const (
 	ReasonFailedDeploymentCreate = "FailedDeploymentCreate"
 	ReasonFailedDeploymentUpdate = "FailedDeploymentUpdate"
 
+	ReasonFailedHPAGet    = "FailedHorizontalPodAutoscalerGet"
+	ReasonFailedHPACreate = "FailedHorizontalPodAutoscalerCreate"
+	ReasonFailedHPAUpdate = "FailedHorizontalPodAutoscalerUpdate"
+	ReasonFailedHPADelete = "FailedHorizontalPodAutoscalerDelete"
+
+	ReasonFailedIngressGet      = "FailedIngressGet"
+	ReasonFailedIngressCreate   = "FailedIngressCreate"
+	ReasonFailedIngressUpdate   = "FailedIngressUpdate"
+	ReasonFailedIngressDelete   = "FailedIngressDelete"
+	ReasonFailedHTTPRouteGet    = "FailedHTTPRouteGet"
+	ReasonFailedHTTPRouteCreate = "FailedHTTPRouteCreate"
+	ReasonFailedHTTPRouteUpdate = "FailedHTTPRouteUpdate"
+	ReasonFailedHTTPRouteDelete = "FailedHTTPRouteDelete"
+	ReasonLoadBalancerPending   = "LoadBalancerPending"
+
+	ReasonFailedPersistentVolumeClaimGet    = "FailedPersistentVolumeClaimGet"
+	ReasonFailedPersistentVolumeClaimCreate = "FailedPersistentVolumeClaimCreate"
+	ReasonFailedPersistentVolumeClaimUpdate = "FailedPersistentVolumeClaimUpdate"
+	ReasonPersistentVolumeClaimImmutable    = "PersistentVolumeClaimImmutable"
+
 	ReasonFailedSecretCompose = "FailedSecretCompose"
 	ReasonFailedSecretGet     = "FailedSecretGet"
 	ReasonFailedSecretCreate  = "FailedSecretCreate"
 	ReasonFailedSecretUpdate  = "FailedSecretUpdate"
 
+	ReasonRedisConnectionInvalid    = "RedisConnectionInvalid"
+	ReasonRedisUnreachable          = "RedisUnreachable"
+	ReasonRedisAuthenticationFailed = "RedisAuthenticationFailed"
+	ReasonRedisTLSHandshakeFailed   = "RedisTLSHandshakeFailed"
+	ReasonRedisStreamInvalid        = "RedisStreamInvalid"
+	ReasonRedisStreamNotFound       = "RedisStreamNotFound"
+
+	ReasonRedisStreamDeleted             = "RedisStreamDeleted"
+	ReasonRedisConsumerGroupDeleted      = "RedisConsumerGroupDeleted"
+	ReasonRedisCleanupSkipped            = "RedisCleanupSkipped"
+	ReasonFailedRedisStreamDelete        = "FailedRedisStreamDelete"
+	ReasonFailedRedisConsumerGroupDelete = "FailedRedisConsumerGroupDelete"
+
+	ReasonReferenceDoesNotExist = "ReferenceDoesNotExist"
+	ReasonFailedReferenceGet    = "FailedReferenceGet"
+	ReasonFailedReferenceTrack  = "FailedReferenceTrack"
+
 	ReasonStatusConfigMapGetFailed    = "FailedConfigMapGet"
 	ReasonStatusConfigMapDoesNotExist = "FailedConfigMapDoesNotExist"
 	ReasonStatusConfigMapCreateFailed = "FailedConfigMapCreate"
@@ -37,14 +74,31 @@ const (
 	ReasonFailedServiceCreate = "FailedServiceCreate"
 	ReasonFailedServiceUpdate = "FailedServiceUpdate"
 
-	ReasonFailedTriggerList     = "FailedTriggerList"
-	ReasonFailedConfigSerialize = "FailedConfigSerialize"
+	ReasonFailedTriggerList             = "FailedTriggerList"
+	ReasonFailedTriggerFinalizersUpdate = "FailedTriggerFinalizersUpdate"
+	ReasonFailedConfigSerialize         = "FailedConfigSerialize"
 
 	ReasonUnavailableEndpoints = "UnavailableEndpoints"
 	ReasonFailedEndpointsGet   = "FailedEndpointsGet"
 
-	ReasonBrokerDoesNotExist = "BrokerDoesNotExist"
-	ReasonFailedBrokerGet    = "FailedBrokerGet"
+	ReasonBrokerDoesNotExist         = "BrokerDoesNotExist"
+	ReasonFailedBrokerGet            = "FailedBrokerGet"
+	ReasonTriggerNamespaceNotAllowed = "TriggerNamespaceNotAllowed"
+
+	ReasonFailedBrokerCreate = "FailedBrokerCreate"
+	ReasonFailedBrokerUpdate = "FailedBrokerUpdate"
+	ReasonFailedBrokerDelete = "FailedBrokerDelete"
+	ReasonBrokerNotOwned     = "BrokerNotOwned"
+
+	ReasonFailedTriggerGet          = "FailedTriggerGet"
+	ReasonFailedTriggerCreate       = "FailedTriggerCreate"
+	ReasonFailedTriggerUpdate       = "FailedTriggerUpdate"
+	ReasonFailedTriggerDelete       = "FailedTriggerDelete"
+	ReasonTriggerNotOwned           = "TriggerNotOwned"
+	ReasonTriggerFilterNotSupported = "TriggerFilterNotSupported"
 
-	ReasonFailedResolveReference = "FailedResolveReference"
+	ReasonTargetDoesNotExist          = "TargetDoesNotExist"
+	ReasonFailedResolveTarget         = "FailedResolveTarget"
+	ReasonDeadLetterSinkDoesNotExist  = "DeadLetterSinkDoesNotExist"
+	ReasonFailedResolveDeadLetterSink = "FailedResolveDeadLetterSink"
 )
@@ -0,0 +1,49 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	"github.com/triggermesh/triggermesh-core/pkg/reconciler/resources"
+)
+
+// PodTemplateOptions translates user provided pod customizations into options
+// for the pod metadata, the pod spec and the main container.
+//
+// Metadata options must be applied before the ones set by the controller, so
+// that managed labels cannot be overridden.
+func PodTemplateOptions(pt *eventingv1alpha1.PodTemplate) ([]resources.MetaOption, []resources.PodSpecOption, []resources.ContainerOption) {
+	if pt == nil {
+		return nil, nil, nil
+	}
+
+	var mopts []resources.MetaOption
+	for k, v := range pt.Labels {
+		mopts = append(mopts, resources.MetaAddLabel(k, v))
+	}
+	for k, v := range pt.Annotations {
+		mopts = append(mopts, resources.MetaAddAnnotation(k, v))
+	}
+
+	var psopts []resources.PodSpecOption
+	if len(pt.NodeSelector) != 0 {
+		psopts = append(psopts, resources.PodSpecWithNodeSelector(pt.NodeSelector))
+	}
+	if len(pt.Tolerations) != 0 {
+		psopts = append(psopts, resources.PodSpecWithTolerations(pt.Tolerations...))
+	}
+	if pt.Affinity != nil {
+		psopts = append(psopts, resources.PodSpecWithAffinity(pt.Affinity))
+	}
+	if pt.PriorityClassName != "" {
+		psopts = append(psopts, resources.PodSpecWithPriorityClassName(pt.PriorityClassName))
+	}
+
+	var copts []resources.ContainerOption
+	if pt.Resources != nil {
+		copts = append(copts, resources.ContainerWithResources(*pt.Resources))
+	}
+
+	return mopts, psopts, copts
+}
@@ -10,17 +10,24 @@ import (
 	"go.uber.org/zap"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	autoscalingv2listers "k8s.io/client-go/listers/autoscaling/v2"
 	corev1listers "k8s.io/client-go/listers/core/v1"
+	networkingv1listers "k8s.io/client-go/listers/networking/v1"
+	"k8s.io/client-go/tools/cache"
 	"knative.dev/eventing/pkg/apis/duck"
 	k8sclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/injection/clients/dynamicclient"
 	"knative.dev/pkg/logging"
 	pkgreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/tracker"
 
 	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
 	"github.com/triggermesh/triggermesh-core/pkg/reconciler/resources"
@@ -35,6 +42,7 @@ const (
 	// in unprivileged environments.
 	brokerContainerPort = 8080
 
+	defaultBrokerReplicas    = 1
 	defaultBrokerServicePort = 80
 	metricsServicePort       = 9090
 )
@@ -45,26 +53,41 @@ type BrokerReconciler interface {
 
 type brokerReconciler struct {
 	client           kubernetes.Interface
+	dynamicClient    dynamic.Interface
 	deploymentLister appsv1listers.DeploymentLister
+	hpaLister        autoscalingv2listers.HorizontalPodAutoscalerLister
 	serviceLister    corev1listers.ServiceLister
 	endpointsLister  corev1listers.EndpointsLister
+	ingressLister    networkingv1listers.IngressLister
+	tracker          tracker.Interface
+	httpRouteLister  func() (cache.GenericLister, error)
 	image            string
 	// TODO remove when using releases
 	pullPolicy corev1.PullPolicy
 }
 
 func NewBrokerReconciler(ctx context.Context,
+	tracker tracker.Interface,
 	deploymentLister appsv1listers.DeploymentLister,
+	hpaLister autoscalingv2listers.HorizontalPodAutoscalerLister,
 	serviceLister corev1listers.ServiceLister,
 	endpointsLister corev1listers.EndpointsLister,
+	ingressLister networkingv1listers.IngressLister,
 	image string,
 	pullPolicy corev1.PullPolicy) BrokerReconciler {
 
+	dc := dynamicclient.Get(ctx)
+
 	return &brokerReconciler{
 		client:           k8sclient.Get(ctx),
+		dynamicClient:    dc,
 		deploymentLister: deploymentLister,
+		hpaLister:        hpaLister,
 		serviceLister:    serviceLister,
 		endpointsLister:  endpointsLister,
+		ingressLister:    ingressLister,
+		tracker:          tracker,
+		httpRouteLister:  newHTTPRouteListerFactory(ctx, dc, tracker),
 		image:            image,
 		pullPolicy:       pullPolicy,
 	}
@@ -76,11 +99,19 @@ func (r *brokerReconciler) Reconcile(ctx context.Context, rb eventingv1alpha1.Re
 		return nil, nil, err
 	}
 
+	if err := r.reconcileHPA(ctx, rb, d); err != nil {
+		return d, nil, err
+	}
+
 	svc, err := r.reconcileService(ctx, rb)
 	if err != nil {
 		return d, nil, err
 	}
 
+	if err := r.reconcileExposure(ctx, rb, svc); err != nil {
+		return d, svc, err
+	}
+
 	_, err = r.reconcileEndpoints(ctx, svc, rb)
 	if err != nil {
 		return d, nil, err
@@ -103,7 +134,7 @@ func buildBrokerDeployment(rb eventingv1alpha1.ReconcilableBroker, sa *corev1.Se
 		resources.ContainerAddEnvFromValue("KUBERNETES_BROKER_CONFIG_SECRET_KEY", ConfigSecretKey),
 		resources.ContainerAddEnvFromValue("KUBERNETES_STATUS_CONFIGMAP_NAME", cm.Name),
 		resources.ContainerWithImagePullPolicy(pullPolicy),
-		resources.ContainerAddPort("httpce", brokerContainerPort),
+		resources.ContainerAddPort(brokerHTTPPortName, brokerContainerPort),
 		resources.ContainerAddPort("metrics", metricsServicePort),
 	}
 
@@ -111,7 +142,33 @@ func buildBrokerDeployment(rb eventingv1alpha1.ReconcilableBroker, sa *corev1.Se
 		copts = append(copts, resources.ContainerAddEnvFromValue("KUBERNETES_OBSERVABILITY_CONFIGMAP_NAME", bs.Observability.ValueFromConfigMap))
 	}
 
+	mopts, psopts, ptcopts := PodTemplateOptions(bs.PodTemplate)
+	copts = append(copts, ptcopts...)
+
+	// Needed for prometheus PodMonitor.
+	mopts = append(mopts,
+		resources.MetaAddLabel(resources.AppPartOfLabel, resources.PartOf),
+		resources.MetaAddLabel(resources.AppManagedByLabel, resources.ManagedBy),
+	)
+
+	psopts = append(psopts,
+		resources.PodSpecWithServiceAccountName(sa.Name),
+		resources.PodSpecAddContainer(
+			resources.NewContainer("broker", image, copts...)))
+
 	dn := name + "-" + rb.GetOwnedObjectsSuffix() + "-" + brokerResourceSuffix
+
+	// When autoscaling is enabled the number of replicas is managed by the
+	// HorizontalPodAutoscaler and left empty at the desired Deployment.
+	var ropts []resources.DeploymentOption
+	if bs.Autoscaling == nil {
+		replicas := int32(defaultBrokerReplicas)
+		if bs.Replicas != nil {
+			replicas = *bs.Replicas
+		}
+		ropts = append(ropts, resources.DeploymentSetReplicas(replicas))
+	}
+
 	d := resources.NewDeployment(ns, dn,
 		resources.DeploymentWithMetaOptions(
 			resources.MetaAddLabel(resources.AppNameLabel, AppAnnotationValue(rb)),
@@ -120,19 +177,16 @@ func buildBrokerDeployment(rb eventingv1alpha1.ReconcilableBroker, sa *corev1.Se
 			resources.MetaAddLabel(resources.AppManagedByLabel, resources.ManagedBy),
 			resources.MetaAddLabel(resources.AppInstanceLabel, dn),
 			resources.MetaAddOwner(meta, rb.GetGroupVersionKind())),
-		resources.DeploymentAddSelectorForTemplate(resources.AppComponentLabel, brokerDeploymentComponentLabel),
-		resources.DeploymentAddSelectorForTemplate(resources.AppInstanceLabel, dn),
-		resources.DeploymentSetReplicas(1),
 		resources.DeploymentWithTemplateSpecOptions(
-			// Needed for prometheus PodMonitor.
-			resources.PodTemplateSpecWithMetaOptions(
-				resources.MetaAddLabel(resources.AppPartOfLabel, resources.PartOf),
-				resources.MetaAddLabel(resources.AppManagedByLabel, resources.ManagedBy),
-			),
-			resources.PodTemplateSpecWithPodSpecOptions(
-				resources.PodSpecWithServiceAccountName(sa.Name),
-				resources.PodSpecAddContainer(
-					resources.NewContainer("broker", image, copts...)))))
+			resources.PodTemplateSpecWithMetaOptions(mopts...),
+			resources.PodTemplateSpecWithPodSpecOptions(psopts...)),
+		// Selector labels are set after the template ones to take precedence.
+		resources.DeploymentAddSelectorForTemplate(resources.AppComponentLabel, brokerDeploymentComponentLabel),
+		resources.DeploymentAddSelectorForTemplate(resources.AppInstanceLabel, dn))
+
+	for _, o := range ropts {
+		o(d)
+	}
 
 	if len(extraOptions) != 0 {
 		for _, o := range extraOptions {
@@ -160,6 +214,11 @@ func (r *brokerReconciler) reconcileDeployment(
 			desired.Status = current.Status
 			desired.ResourceVersion = current.ResourceVersion
 
+			// Keep the replicas set by the HorizontalPodAutoscaler.
+			if desired.Spec.Replicas == nil {
+				desired.Spec.Replicas = current.Spec.Replicas
+			}
+
 			current, err = r.client.AppsV1().Deployments(desired.Namespace).Update(ctx, desired, metav1.UpdateOptions{})
 			if err != nil {
 				fullname := types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}
@@ -199,6 +258,104 @@ func (r *brokerReconciler) reconcileDeployment(
 	return current, nil
 }
 
+func buildBrokerHPA(rb eventingv1alpha1.ReconcilableBroker, d *appsv1.Deployment) *autoscalingv2.HorizontalPodAutoscaler {
+	meta := rb.GetObjectMeta()
+	as := rb.GetReconcilableBrokerSpec().Autoscaling
+
+	minReplicas := int32(defaultBrokerReplicas)
+	if as.MinReplicas != nil {
+		minReplicas = *as.MinReplicas
+	}
+
+	hopts := []resources.HorizontalPodAutoscalerOption{
+		resources.HorizontalPodAutoscalerWithMetaOptions(
+			resources.MetaAddLabel(resources.AppNameLabel, AppAnnotationValue(rb)),
+			resources.MetaAddLabel(resources.AppComponentLabel, "broker-hpa"),
+			resources.MetaAddLabel(resources.AppPartOfLabel, resources.PartOf),
+			resources.MetaAddLabel(resources.AppManagedByLabel, resources.ManagedBy),
+			resources.MetaAddLabel(resources.AppInstanceLabel, d.Name),
+			resources.MetaAddOwner(meta, rb.GetGroupVersionKind())),
+		resources.HorizontalPodAutoscalerWithDeploymentTarget(d.Name),
+		resources.HorizontalPodAutoscalerSetMinReplicas(minReplicas),
+		resources.HorizontalPodAutoscalerSetMaxReplicas(as.MaxReplicas),
+	}
+
+	if as.TargetCPUUtilizationPercentage != nil {
+		hopts = append(hopts, resources.HorizontalPodAutoscalerAddCPUUtilizationMetric(*as.TargetCPUUtilizationPercentage))
+	}
+
+	if len(as.Metrics) != 0 {
+		hopts = append(hopts, resources.HorizontalPodAutoscalerAddMetrics(as.Metrics...))
+	}
+
+	return resources.NewHorizontalPodAutoscaler(d.Namespace, d.Name, hopts...)
+}
+
+// reconcileHPA makes sure that a HorizontalPodAutoscaler exists for the broker
+// Deployment when autoscaling is configured, and that it is removed otherwise.
+func (r *brokerReconciler) reconcileHPA(ctx context.Context, rb eventingv1alpha1.ReconcilableBroker, d *appsv1.Deployment) error {
+	fullname := types.NamespacedName{Namespace: d.Namespace, Name: d.Name}
+	current, err := r.hpaLister.HorizontalPodAutoscalers(d.Namespace).Get(d.Name)
+	if err != nil && !apierrs.IsNotFound(err) {
+		logging.FromContext(ctx).Error("Unable to get broker horizontal pod autoscaler", zap.String("hpa", fullname.String()), zap.Error(err))
+		rb.GetReconcilableBrokerStatus().MarkBrokerDeploymentFailed(ReasonFailedHPAGet, "Failed to get broker horizontal pod autoscaler")
+
+		return pkgreconciler.NewEvent(corev1.EventTypeWarning, ReasonFailedHPAGet,
+			"Failed to get broker horizontal pod autoscaler %s: %w", fullname, err)
+	}
+
+	if rb.GetReconcilableBrokerSpec().Autoscaling == nil {
+		// Only remove autoscalers that this broker owns.
+		if current == nil || !metav1.IsControlledBy(current, rb.GetObjectMeta()) {
+			return nil
+		}
+
+		err = r.client.AutoscalingV2().HorizontalPodAutoscalers(d.Namespace).Delete(ctx, d.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrs.IsNotFound(err) {
+			logging.FromContext(ctx).Error("Unable to delete broker horizontal pod autoscaler", zap.String("hpa", fullname.String()), zap.Error(err))
+			rb.GetReconcilableBrokerStatus().MarkBrokerDeploymentFailed(ReasonFailedHPADelete, "Failed to delete broker horizontal pod autoscaler")
+
+			return pkgreconciler.NewEvent(corev1.EventTypeWarning, ReasonFailedHPADelete,
+				"Failed to delete broker horizontal pod autoscaler %s: %w", fullname, err)
+		}
+
+		return nil
+	}
+
+	desired := buildBrokerHPA(rb, d)
+
+	if current == nil {
+		// The object has not been found, create it.
+		_, err = r.client.AutoscalingV2().HorizontalPodAutoscalers(desired.Namespace).Create(ctx, desired, metav1.CreateOptions{})
+		if err != nil {
+			logging.FromContext(ctx).Error("Unable to create broker horizontal pod autoscaler", zap.String("hpa", fullname.String()), zap.Error(err))
+			rb.GetReconcilableBrokerStatus().MarkBrokerDeploymentFailed(ReasonFailedHPACreate, "Failed to create broker horizontal pod autoscaler")
+
+			return pkgreconciler.NewEvent(corev1.EventTypeWarning, ReasonFailedHPACreate,
+				"Failed to create broker horizontal pod autoscaler %s: %w", fullname, err)
+		}
+
+		return nil
+	}
+
+	// Compare current object with desired, update if needed.
+	if !semantic.Semantic.DeepEqual(desired, current) {
+		desired.Status = current.Status
+		desired.ResourceVersion = current.ResourceVersion
+
+		_, err = r.client.AutoscalingV2().HorizontalPodAutoscalers(desired.Namespace).Update(ctx, desired, metav1.UpdateOptions{})
+		if err != nil {
+			logging.FromContext(ctx).Error("Unable to update broker horizontal pod autoscaler", zap.String("hpa", fullname.String()), zap.Error(err))
+			rb.GetReconcilableBrokerStatus().MarkBrokerDeploymentFailed(ReasonFailedHPAUpdate, "Failed to update broker horizontal pod autoscaler")
+
+			return pkgreconciler.NewEvent(corev1.EventTypeWarning, ReasonFailedHPAUpdate,
+				"Failed to update broker horizontal pod autoscaler %s: %w", fullname, err)
+		}
+	}
+
+	return nil
+}
+
 func buildBrokerService(rb eventingv1alpha1.ReconcilableBroker) *corev1.Service {
 	meta := rb.GetObjectMeta()
 	ns, name := meta.GetNamespace(), meta.GetName()
@@ -209,6 +366,11 @@ func buildBrokerService(rb eventingv1alpha1.ReconcilableBroker) *corev1.Service
 		brokerPort = *bs.Port
 	}
 
+	serviceType := corev1.ServiceTypeClusterIP
+	if bs.Exposure != nil && bs.Exposure.ServiceType != "" {
+		serviceType = bs.Exposure.ServiceType
+	}
+
 	sn := name + "-" + rb.GetOwnedObjectsSuffix() + "-" + brokerResourceSuffix
 	return resources.NewService(ns, sn,
 		resources.ServiceWithMetaOptions(
@@ -218,10 +380,10 @@ func buildBrokerService(rb eventingv1alpha1.ReconcilableBroker) *corev1.Service
 			resources.MetaAddLabel(resources.AppManagedByLabel, resources.ManagedBy),
 			resources.MetaAddLabel(resources.AppInstanceLabel, sn),
 			resources.MetaAddOwner(meta, rb.GetGroupVersionKind())),
-		resources.ServiceSetType(corev1.ServiceTypeClusterIP),
+		resources.ServiceSetType(serviceType),
 		resources.ServiceAddSelectorLabel(resources.AppComponentLabel, brokerDeploymentComponentLabel),
 		resources.ServiceAddSelectorLabel(resources.AppInstanceLabel, sn),
-		resources.ServiceAddPort("httpce", int32(brokerPort), brokerContainerPort))
+		resources.ServiceAddPort(brokerHTTPPortName, int32(brokerPort), brokerContainerPort))
 }
 
 func (r *brokerReconciler) reconcileService(ctx context.Context, rb eventingv1alpha1.ReconcilableBroker) (*corev1.Service, error) {
@@ -0,0 +1,88 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"knative.dev/pkg/ptr"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	"github.com/triggermesh/triggermesh-core/pkg/reconciler/resources"
+	tresources "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing/resources"
+	tmtv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing/v1alpha1"
+)
+
+func TestBuildBrokerDeployment(t *testing.T) {
+	cpu := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m")},
+	}
+
+	testCases := map[string]struct {
+		spec             eventingv1alpha1.CommonBrokerSpec
+		expectedReplicas *int32
+	}{
+		"default replicas": {
+			expectedReplicas: ptr.Int32(1),
+		},
+		"fixed replicas": {
+			spec:             eventingv1alpha1.CommonBrokerSpec{Replicas: ptr.Int32(3)},
+			expectedReplicas: ptr.Int32(3),
+		},
+		"autoscaling": {
+			spec: eventingv1alpha1.CommonBrokerSpec{Autoscaling: &eventingv1alpha1.Autoscaling{MaxReplicas: 3}},
+		},
+		"pod template": {
+			spec: eventingv1alpha1.CommonBrokerSpec{PodTemplate: &eventingv1alpha1.PodTemplate{
+				Labels: map[string]string{
+					"team":                      "events",
+					resources.AppComponentLabel: "override",
+				},
+				Annotations:       map[string]string{"example.com/note": "test"},
+				Resources:         &cpu,
+				NodeSelector:      map[string]string{"disk": "ssd"},
+				Tolerations:       []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}},
+				PriorityClassName: "high",
+			}},
+			expectedReplicas: ptr.Int32(1),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			b := tmtv1alpha1.NewMemoryBroker(tresources.TestNamespace, tresources.TestName)
+			b.Spec.Broker = tc.spec
+
+			d := buildBrokerDeployment(b,
+				&corev1.ServiceAccount{}, &corev1.Secret{}, &corev1.ConfigMap{},
+				"image", corev1.PullIfNotPresent)
+
+			assert.Equal(t, tc.expectedReplicas, d.Spec.Replicas)
+
+			pts := d.Spec.Template
+			require.Len(t, pts.Spec.Containers, 1)
+
+			// Managed labels must always take precedence.
+			assert.Equal(t, brokerDeploymentComponentLabel, pts.Labels[resources.AppComponentLabel])
+			assert.Equal(t, resources.PartOf, pts.Labels[resources.AppPartOfLabel])
+
+			pt := tc.spec.PodTemplate
+			if pt == nil {
+				return
+			}
+
+			assert.Equal(t, "events", pts.Labels["team"])
+			assert.Equal(t, pt.Annotations, pts.Annotations)
+			assert.Equal(t, *pt.Resources, pts.Spec.Containers[0].Resources)
+			assert.Equal(t, pt.NodeSelector, pts.Spec.NodeSelector)
+			assert.Equal(t, pt.Tolerations, pts.Spec.Tolerations)
+			assert.Equal(t, pt.PriorityClassName, pts.Spec.PriorityClassName)
+		})
+	}
+}
@@ -0,0 +1,388 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/apis"
+	pkgduck "knative.dev/pkg/apis/duck"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/tracker"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	"github.com/triggermesh/triggermesh-core/pkg/reconciler/resources"
+	"github.com/triggermesh/triggermesh-core/pkg/reconciler/semantic"
+)
+
+// reconcileExposure makes sure that the objects that expose the broker outside
+// the cluster exist, and reports the resulting public URL.
+func (r *brokerReconciler) reconcileExposure(ctx context.Context, rb eventingv1alpha1.ReconcilableBroker, svc *corev1.Service) error {
+	ingressURL, err := r.reconcileIngress(ctx, rb, svc)
+	if err != nil {
+		return err
+	}
+
+	routeURL, err := r.reconcileHTTPRoute(ctx, rb, svc)
+	if err != nil {
+		return err
+	}
+
+	status := rb.GetReconcilableBrokerStatus()
+	switch {
+	case ingressURL != nil:
+		status.MarkExposureReady(ingressURL)
+
+	case routeURL != nil:
+		status.MarkExposureReady(routeURL)
+
+	case svc.Spec.Type == corev1.ServiceTypeLoadBalancer:
+		u := loadBalancerURL(svc)
+		if u == nil {
+			status.MarkExposureUnknown(ReasonLoadBalancerPending, "The broker Service load balancer has not been provisioned")
+			return nil
+		}
+		status.MarkExposureReady(u)
+
+	case svc.Spec.Type == corev1.ServiceTypeNodePort:
+		// Node addresses are not known to the controller.
+		status.MarkExposureReady(nil)
+
+	default:
+		status.MarkExposureNotConfigured()
+	}
+
+	return nil
+}
+
+// loadBalancerURL returns the HTTP URL at the first address assigned to the
+// Service's load balancer.
+func loadBalancerURL(svc *corev1.Service) *apis.URL {
+	if len(svc.Status.LoadBalancer.Ingress) == 0 {
+		return nil
+	}
+
+	lb := svc.Status.LoadBalancer.Ingress[0]
+	host := lb.Hostname
+	if host == "" {
+		host = lb.IP
+	}
+	if host == "" {
+		return nil
+	}
+
+	for _, p := range svc.Spec.Ports {
+		if p.Name == brokerHTTPPortName {
+			host = hostPort(host, p.Port, defaultBrokerServicePort)
+		}
+	}
+
+	return apis.HTTP(host)
+}
+
+func buildBrokerIngress(rb eventingv1alpha1.ReconcilableBroker, svc *corev1.Service) *networkingv1.Ingress {
+	meta := rb.GetObjectMeta()
+	ing := rb.GetReconcilableBrokerSpec().Exposure.Ingress
+
+	mopts := []resources.MetaOption{
+		resources.MetaAddLabel(resources.AppNameLabel, AppAnnotationValue(rb)),
+		resources.MetaAddLabel(resources.AppComponentLabel, "broker-ingress"),
+		resources.MetaAddLabel(resources.AppPartOfLabel, resources.PartOf),
+		resources.MetaAddLabel(resources.AppManagedByLabel, resources.ManagedBy),
+		resources.MetaAddLabel(resources.AppInstanceLabel, svc.Name),
+		resources.MetaAddOwner(meta, rb.GetGroupVersionKind()),
+	}
+	for k, v := range ing.Annotations {
+		mopts = append(mopts, resources.MetaAddAnnotation(k, v))
+	}
+
+	iopts := []resources.IngressOption{
+		resources.IngressWithMetaOptions(mopts...),
+		resources.IngressAddServiceRule(ing.Host, svc.Name, brokerHTTPPortName),
+	}
+
+	if ing.ClassName != nil {
+		iopts = append(iopts, resources.IngressSetClassName(*ing.ClassName))
+	}
+
+	if ing.TLSSecret != nil {
+		iopts = append(iopts, resources.IngressAddTLS(*ing.TLSSecret, ing.Host))
+	}
+
+	return resources.NewIngress(svc.Namespace, svc.Name, iopts...)
+}
+
+// reconcileIngress makes sure that an Ingress exists for the broker Service
+// when configured, and that it is removed otherwise. It returns the public URL
+// served by the Ingress.
+func (r *brokerReconciler) reconcileIngress(ctx context.Context, rb eventingv1alpha1.ReconcilableBroker, svc *corev1.Service) (*apis.URL, error) {
+	fullname := types.NamespacedName{Namespace: svc.Namespace, Name: svc.Name}
+	current, err := r.ingressLister.Ingresses(svc.Namespace).Get(svc.Name)
+	if err != nil && !apierrs.IsNotFound(err) {
+		logging.FromContext(ctx).Error("Unable to get broker ingress", zap.String("ingress", fullname.String()), zap.Error(err))
+		rb.GetReconcilableBrokerStatus().MarkExposureFailed(ReasonFailedIngressGet, "Failed to get broker ingress")
+
+		return nil, pkgreconciler.NewEvent(corev1.EventTypeWarning, ReasonFailedIngressGet,
+			"Failed to get broker ingress %s: %w", fullname, err)
+	}
+
+	exp := rb.GetReconcilableBrokerSpec().Exposure
+	if exp == nil || exp.Ingress == nil {
+		// Only remove ingresses that this broker owns.
+		if current == nil || !metav1.IsControlledBy(current, rb.GetObjectMeta()) {
+			return nil, nil
+		}
+
+		err = r.client.NetworkingV1().Ingresses(svc.Namespace).Delete(ctx, svc.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrs.IsNotFound(err) {
+			logging.FromContext(ctx).Error("Unable to delete broker ingress", zap.String("ingress", fullname.String()), zap.Error(err))
+			rb.GetReconcilableBrokerStatus().MarkExposureFailed(ReasonFailedIngressDelete, "Failed to delete broker ingress")
+
+			return nil, pkgreconciler.NewEvent(corev1.EventTypeWarning, ReasonFailedIngressDelete,
+				"Failed to delete broker ingress %s: %w", fullname, err)
+		}
+
+		return nil, nil
+	}
+
+	desired := buildBrokerIngress(rb, svc)
+
+	switch {
+	case current == nil:
+		// The object has not been found, create it.
+		_, err = r.client.NetworkingV1().Ingresses(desired.Namespace).Create(ctx, desired, metav1.CreateOptions{})
+		if err != nil {
+			logging.FromContext(ctx).Error("Unable to create broker ingress", zap.String("ingress", fullname.String()), zap.Error(err))
+			rb.GetReconcilableBrokerStatus().MarkExposureFailed(ReasonFailedIngressCreate, "Failed to create broker ingress")
+
+			return nil, pkgreconciler.NewEvent(corev1.EventTypeWarning, ReasonFailedIngressCreate,
+				"Failed to create broker ingress %s: %w", fullname, err)
+		}
+
+	case !semantic.Semantic.DeepEqual(desired, current):
+		desired.Status = current.Status
+		desired.ResourceVersion = current.ResourceVersion
+
+		_, err = r.client.NetworkingV1().Ingresses(desired.Namespace).Update(ctx, desired, metav1.UpdateOptions{})
+		if err != nil {
+			logging.FromContext(ctx).Error("Unable to update broker ingress", zap.String("ingress", fullname.String()), zap.Error(err))
+			rb.GetReconcilableBrokerStatus().MarkExposureFailed(ReasonFailedIngressUpdate, "Failed to update broker ingress")
+
+			return nil, pkgreconciler.NewEvent(corev1.EventTypeWarning, ReasonFailedIngressUpdate,
+				"Failed to update broker ingress %s: %w", fullname, err)
+		}
+	}
+
+	if exp.Ingress.TLSSecret != nil {
+		return apis.HTTPS(exp.Ingress.Host), nil
+	}
+	return apis.HTTP(exp.Ingress.Host), nil
+}
+
+func buildBrokerHTTPRoute(rb eventingv1alpha1.ReconcilableBroker, svc *corev1.Service) *resources.HTTPRoute {
+	meta := rb.GetObjectMeta()
+	hr := rb.GetReconcilableBrokerSpec().Exposure.HTTPRoute
+
+	ropts := []resources.HTTPRouteOption{
+		resources.HTTPRouteWithMetaOptions(
+			resources.MetaAddLabel(resources.AppNameLabel, AppAnnotationValue(rb)),
+			resources.MetaAddLabel(resources.AppComponentLabel, "broker-httproute"),
+			resources.MetaAddLabel(resources.AppPartOfLabel, resources.PartOf),
+			resources.MetaAddLabel(resources.AppManagedByLabel, resources.ManagedBy),
+			resources.MetaAddLabel(resources.AppInstanceLabel, svc.Name),
+			resources.MetaAddOwner(meta, rb.GetGroupVersionKind())),
+		resources.HTTPRouteAddHostname(hr.Hostname),
+	}
+
+	for _, pr := range hr.ParentRefs {
+		ropts = append(ropts, resources.HTTPRouteAddParentRef(resources.HTTPRouteParentRef{
+			Name:        pr.Name,
+			Namespace:   pr.Namespace,
+			SectionName: pr.SectionName,
+		}))
+	}
+
+	for _, p := range svc.Spec.Ports {
+		if p.Name == brokerHTTPPortName {
+			ropts = append(ropts, resources.HTTPRouteAddServiceRule(svc.Name, p.Port))
+		}
+	}
+
+	return resources.NewHTTPRoute(svc.Namespace, svc.Name, ropts...)
+}
+
+// newHTTPRouteListerFactory returns a function that provides a lister for
+// HTTPRoutes. Routes are watched once a broker needs them, since the Gateway
+// API might not be installed, and route changes are notified to the tracker.
+func newHTTPRouteListerFactory(ctx context.Context, client dynamic.Interface, t tracker.Interface) func() (cache.GenericLister, error) {
+	informerFactory := &pkgduck.CachedInformerFactory{
+		Delegate: &pkgduck.EnqueueInformerFactory{
+			Delegate: &dynamicInformerFactory{
+				client:      client,
+				stopChannel: ctx.Done(),
+			},
+			EventHandler: controller.HandleAll(t.OnChanged),
+		},
+	}
+
+	return func() (cache.GenericLister, error) {
+		_, l, err := informerFactory.Get(ctx, resources.HTTPRouteGVR)
+		return l, err
+	}
+}
+
+// dynamicInformerFactory creates informers for unstructured objects.
+type dynamicInformerFactory struct {
+	client      dynamic.Interface
+	stopChannel <-chan struct{}
+}
+
+var _ pkgduck.InformerFactory = (*dynamicInformerFactory)(nil)
+
+func (f *dynamicInformerFactory) Get(ctx context.Context, gvr schema.GroupVersionResource) (cache.SharedIndexInformer, cache.GenericLister, error) {
+	// Fail early when the resource is not served by the cluster.
+	if _, err := f.client.Resource(gvr).List(ctx, metav1.ListOptions{Limit: 1}); err != nil {
+		return nil, nil, err
+	}
+
+	inf := dynamicinformer.NewFilteredDynamicInformer(f.client, gvr, metav1.NamespaceAll, 0,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, nil)
+
+	go inf.Informer().Run(f.stopChannel)
+
+	if !cache.WaitForCacheSync(f.stopChannel, inf.Informer().HasSynced) {
+		return nil, nil, fmt.Errorf("failed starting informer for %v", gvr)
+	}
+
+	return inf.Informer(), inf.Lister(), nil
+}
+
+// getHTTPRoute returns the broker's HTTPRoute from the informer cache, or nil
+// if it does not exist. The broker is tracked to be notified of route changes.
+func (r *brokerReconciler) getHTTPRoute(rb eventingv1alpha1.ReconcilableBroker, namespace, name string) (*unstructured.Unstructured, error) {
+	if err := r.tracker.TrackReference(tracker.Reference{
+		APIVersion: resources.HTTPRouteGVR.GroupVersion().String(),
+		Kind:       "HTTPRoute",
+		Namespace:  namespace,
+		Name:       name,
+	}, rb); err != nil {
+		return nil, err
+	}
+
+	lister, err := r.httpRouteLister()
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := lister.ByNamespace(namespace).Get(name)
+	switch {
+	case apierrs.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil, fmt.Errorf("unexpected HTTPRoute object type %T", obj)
+	}
+	return u, nil
+}
+
+// reconcileHTTPRoute makes sure that a Gateway API HTTPRoute exists for the
+// broker Service when configured, and that it is removed otherwise. It returns
+// the public URL served by the route.
+func (r *brokerReconciler) reconcileHTTPRoute(ctx context.Context, rb eventingv1alpha1.ReconcilableBroker, svc *corev1.Service) (*apis.URL, error) {
+	status := rb.GetReconcilableBrokerStatus()
+	exp := rb.GetReconcilableBrokerSpec().Exposure
+	configured := exp != nil && exp.HTTPRoute != nil
+
+	// Routes are only looked up by brokers that use them, or that created
+	// one that needs to be removed.
+	if !configured && !status.IsHTTPRouteCreated() {
+		return nil, nil
+	}
+
+	fullname := types.NamespacedName{Namespace: svc.Namespace, Name: svc.Name}
+	routes := r.dynamicClient.Resource(resources.HTTPRouteGVR).Namespace(svc.Namespace)
+
+	current, err := r.getHTTPRoute(rb, svc.Namespace, svc.Name)
+	if err != nil {
+		logging.FromContext(ctx).Error("Unable to get broker HTTPRoute", zap.String("httproute", fullname.String()), zap.Error(err))
+		status.MarkExposureFailed(ReasonFailedHTTPRouteGet, "Failed to get broker HTTPRoute")
+
+		return nil, pkgreconciler.NewEvent(corev1.EventTypeWarning, ReasonFailedHTTPRouteGet,
+			"Failed to get broker HTTPRoute %s: %w", fullname, err)
+	}
+
+	if !configured {
+		// Only remove routes that this broker owns.
+		if current != nil && metav1.IsControlledBy(current, rb.GetObjectMeta()) {
+			err = routes.Delete(ctx, svc.Name, metav1.DeleteOptions{})
+			if err != nil && !apierrs.IsNotFound(err) {
+				logging.FromContext(ctx).Error("Unable to delete broker HTTPRoute", zap.String("httproute", fullname.String()), zap.Error(err))
+				status.MarkExposureFailed(ReasonFailedHTTPRouteDelete, "Failed to delete broker HTTPRoute")
+
+				return nil, pkgreconciler.NewEvent(corev1.EventTypeWarning, ReasonFailedHTTPRouteDelete,
+					"Failed to delete broker HTTPRoute %s: %w", fullname, err)
+			}
+		}
+
+		status.SetHTTPRouteCreated(false)
+		return nil, nil
+	}
+
+	desired := buildBrokerHTTPRoute(rb, svc)
+	u, err := resources.HTTPRouteToUnstructured(desired)
+	if err != nil {
+		// Unexpected path, the route is built from known types.
+		return nil, err
+	}
+
+	if current == nil {
+		// The object has not been found, create it.
+		if _, err = routes.Create(ctx, u, metav1.CreateOptions{}); err != nil {
+			logging.FromContext(ctx).Error("Unable to create broker HTTPRoute", zap.String("httproute", fullname.String()), zap.Error(err))
+			status.MarkExposureFailed(ReasonFailedHTTPRouteCreate, "Failed to create broker HTTPRoute")
+
+			return nil, pkgreconciler.NewEvent(corev1.EventTypeWarning, ReasonFailedHTTPRouteCreate,
+				"Failed to create broker HTTPRoute %s: %w", fullname, err)
+		}
+	} else if cr, err := resources.HTTPRouteFromUnstructured(current); err != nil ||
+		!semantic.Semantic.DeepDerivative(desired.Labels, cr.Labels) ||
+		!semantic.Semantic.DeepDerivative(desired.OwnerReferences, cr.OwnerReferences) ||
+		!semantic.Semantic.DeepDerivative(desired.Spec, cr.Spec) {
+		u.SetResourceVersion(current.GetResourceVersion())
+
+		if _, err = routes.Update(ctx, u, metav1.UpdateOptions{}); err != nil {
+			logging.FromContext(ctx).Error("Unable to update broker HTTPRoute", zap.String("httproute", fullname.String()), zap.Error(err))
+			status.MarkExposureFailed(ReasonFailedHTTPRouteUpdate, "Failed to update broker HTTPRoute")
+
+			return nil, pkgreconciler.NewEvent(corev1.EventTypeWarning, ReasonFailedHTTPRouteUpdate,
+				"Failed to update broker HTTPRoute %s: %w", fullname, err)
+		}
+	}
+
+	status.SetHTTPRouteCreated(true)
+
+	if exp.HTTPRoute.HTTPS {
+		return apis.HTTPS(exp.HTTPRoute.Hostname), nil
+	}
+	return apis.HTTP(exp.HTTPRoute.Hostname), nil
+}
@@ -0,0 +1,241 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/ptr"
+	knt "knative.dev/pkg/reconciler/testing"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	"github.com/triggermesh/triggermesh-core/pkg/reconciler/resources"
+	tresources "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing/resources"
+	tmtv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing/v1alpha1"
+)
+
+func TestBrokerExposure(t *testing.T) {
+	testCases := map[string]struct {
+		spec         eventingv1alpha1.CommonBrokerSpec
+		loadBalancer []corev1.LoadBalancerIngress
+
+		expectedType corev1.ServiceType
+		expectedURL  string
+	}{
+		"not exposed": {
+			expectedType: corev1.ServiceTypeClusterIP,
+		},
+		"load balancer pending": {
+			spec: eventingv1alpha1.CommonBrokerSpec{
+				Exposure: &eventingv1alpha1.BrokerExposure{ServiceType: corev1.ServiceTypeLoadBalancer},
+			},
+			expectedType: corev1.ServiceTypeLoadBalancer,
+		},
+		"load balancer with IP": {
+			spec: eventingv1alpha1.CommonBrokerSpec{
+				Exposure: &eventingv1alpha1.BrokerExposure{ServiceType: corev1.ServiceTypeLoadBalancer},
+			},
+			loadBalancer: []corev1.LoadBalancerIngress{{IP: "10.0.0.1"}},
+			expectedType: corev1.ServiceTypeLoadBalancer,
+			expectedURL:  "http://10.0.0.1",
+		},
+		"load balancer with hostname and custom port": {
+			spec: eventingv1alpha1.CommonBrokerSpec{
+				Port:     intPtr(8080),
+				Exposure: &eventingv1alpha1.BrokerExposure{ServiceType: corev1.ServiceTypeLoadBalancer},
+			},
+			loadBalancer: []corev1.LoadBalancerIngress{{Hostname: "lb.example.com", IP: "10.0.0.1"}},
+			expectedType: corev1.ServiceTypeLoadBalancer,
+			expectedURL:  "http://lb.example.com:8080",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			b := tmtv1alpha1.NewMemoryBroker(tresources.TestNamespace, tresources.TestName)
+			b.Spec.Broker = tc.spec
+
+			svc := buildBrokerService(b)
+			assert.Equal(t, tc.expectedType, svc.Spec.Type)
+
+			svc.Status.LoadBalancer.Ingress = tc.loadBalancer
+			u := loadBalancerURL(svc)
+			if tc.expectedURL == "" {
+				assert.Nil(t, u)
+				return
+			}
+			require.NotNil(t, u)
+			assert.Equal(t, tc.expectedURL, u.String())
+		})
+	}
+}
+
+func TestBuildBrokerIngressAndHTTPRoute(t *testing.T) {
+	b := tmtv1alpha1.NewMemoryBroker(tresources.TestNamespace, tresources.TestName)
+	b.Spec.Broker.Exposure = &eventingv1alpha1.BrokerExposure{
+		Ingress: &eventingv1alpha1.BrokerIngress{
+			Host:        "broker.example.com",
+			ClassName:   ptr.String("nginx"),
+			Annotations: map[string]string{"cert-manager.io/cluster-issuer": "letsencrypt"},
+			TLSSecret:   ptr.String("broker-public-tls"),
+		},
+		HTTPRoute: &eventingv1alpha1.BrokerHTTPRoute{
+			Hostname:   "broker.example.com",
+			ParentRefs: []eventingv1alpha1.GatewayReference{{Name: "gateway", Namespace: ptr.String("infra")}},
+		},
+	}
+	svc := buildBrokerService(b)
+
+	ing := buildBrokerIngress(b, svc)
+	assert.Equal(t, svc.Name, ing.Name)
+	assert.Equal(t, "letsencrypt", ing.Annotations["cert-manager.io/cluster-issuer"])
+	assert.Equal(t, "nginx", *ing.Spec.IngressClassName)
+	require.Len(t, ing.Spec.Rules, 1)
+	assert.Equal(t, "broker.example.com", ing.Spec.Rules[0].Host)
+	assert.Equal(t, brokerHTTPPortName, ing.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Port.Name)
+	require.Len(t, ing.Spec.TLS, 1)
+	assert.Equal(t, "broker-public-tls", ing.Spec.TLS[0].SecretName)
+	require.Len(t, ing.OwnerReferences, 1)
+
+	hr := buildBrokerHTTPRoute(b, svc)
+	assert.Equal(t, svc.Name, hr.Name)
+	assert.Equal(t, []string{"broker.example.com"}, hr.Spec.Hostnames)
+	require.Len(t, hr.Spec.ParentRefs, 1)
+	assert.Equal(t, "infra", *hr.Spec.ParentRefs[0].Namespace)
+	require.Len(t, hr.Spec.Rules, 1)
+	assert.Equal(t, svc.Name, hr.Spec.Rules[0].BackendRefs[0].Name)
+	assert.Equal(t, int32(defaultBrokerServicePort), hr.Spec.Rules[0].BackendRefs[0].Port)
+	require.Len(t, hr.OwnerReferences, 1)
+}
+
+func TestReconcileHTTPRoute(t *testing.T) {
+	httpRoute := &eventingv1alpha1.BrokerHTTPRoute{
+		Hostname:   "broker.example.com",
+		ParentRefs: []eventingv1alpha1.GatewayReference{{Name: "gateway"}},
+	}
+
+	testCases := map[string]struct {
+		httpRoute    *eventingv1alpha1.BrokerHTTPRoute
+		routeCreated bool
+		existing     bool
+
+		expectLookup  bool
+		expectRoute   bool
+		expectCreated bool
+		expectURL     string
+	}{
+		"not configured": {},
+		"configured": {
+			httpRoute:     httpRoute,
+			expectLookup:  true,
+			expectRoute:   true,
+			expectCreated: true,
+			expectURL:     "http://broker.example.com",
+		},
+		"configured and existing": {
+			httpRoute:     httpRoute,
+			existing:      true,
+			expectLookup:  true,
+			expectRoute:   true,
+			expectCreated: true,
+			expectURL:     "http://broker.example.com",
+		},
+		"no longer configured": {
+			routeCreated: true,
+			existing:     true,
+			expectLookup: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			b := tmtv1alpha1.NewMemoryBroker(tresources.TestNamespace, tresources.TestName)
+			b.Spec.Broker.Exposure = &eventingv1alpha1.BrokerExposure{HTTPRoute: tc.httpRoute}
+			b.Status.HTTPRouteCreated = tc.routeCreated
+			svc := buildBrokerService(b)
+
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			var objects []runtime.Object
+			if tc.existing {
+				b := b.DeepCopy()
+				b.Spec.Broker.Exposure = &eventingv1alpha1.BrokerExposure{HTTPRoute: httpRoute}
+				u, err := resources.HTTPRouteToUnstructured(buildBrokerHTTPRoute(b, svc))
+				require.NoError(t, err)
+				require.NoError(t, indexer.Add(u))
+				objects = append(objects, u)
+			}
+
+			client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), objects...)
+			lookups := 0
+
+			r := &brokerReconciler{
+				dynamicClient: client,
+				tracker:       &knt.FakeTracker{},
+				httpRouteLister: func() (cache.GenericLister, error) {
+					lookups++
+					return cache.NewGenericLister(indexer, resources.HTTPRouteGVR.GroupResource()), nil
+				},
+			}
+
+			u, err := r.reconcileHTTPRoute(ctx, b, svc)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectLookup, lookups > 0, "unexpected HTTPRoute lookup")
+			if !tc.expectLookup {
+				assert.Empty(t, client.Actions(), "unexpected API calls")
+			}
+			assert.Equal(t, tc.expectCreated, b.Status.HTTPRouteCreated)
+			if tc.expectURL == "" {
+				assert.Nil(t, u)
+			} else {
+				require.NotNil(t, u)
+				assert.Equal(t, tc.expectURL, u.String())
+			}
+
+			_, err = client.Resource(resources.HTTPRouteGVR).Namespace(svc.Namespace).Get(ctx, svc.Name, metav1.GetOptions{})
+			if tc.expectRoute {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, apierrs.IsNotFound(err), "expected HTTPRoute not to exist, got %v", err)
+			}
+		})
+	}
+}
+
+func TestReconcileHTTPRouteUnavailable(t *testing.T) {
+	b := tmtv1alpha1.NewMemoryBroker(tresources.TestNamespace, tresources.TestName)
+	b.Spec.Broker.Exposure = &eventingv1alpha1.BrokerExposure{
+		HTTPRoute: &eventingv1alpha1.BrokerHTTPRoute{Hostname: "broker.example.com"},
+	}
+	svc := buildBrokerService(b)
+
+	r := &brokerReconciler{
+		dynamicClient: dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()),
+		tracker:       &knt.FakeTracker{},
+		httpRouteLister: func() (cache.GenericLister, error) {
+			return nil, errors.New("the server could not find the requested resource")
+		},
+	}
+
+	_, err := r.reconcileHTTPRoute(context.Background(), b, svc)
+	assert.Error(t, err)
+
+	c := b.Status.GetCondition(eventingv1alpha1.MemoryBrokerExposure)
+	require.NotNil(t, c)
+	assert.Equal(t, corev1.ConditionFalse, c.Status)
+	assert.Equal(t, ReasonFailedHTTPRouteGet, c.Reason)
+}
@@ -0,0 +1,202 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"sort"
+
+	"go.uber.org/zap"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/tracker"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	"github.com/triggermesh/triggermesh-core/pkg/reconciler/resources"
+)
+
+const (
+	// ReferencesHashAnnotation is set at the broker pod template with a hash of
+	// the contents of all user referenced Secrets and ConfigMaps, rolling out
+	// the broker pods when any of them change.
+	ReferencesHashAnnotation = "eventing.triggermesh.io/references-hash"
+)
+
+type ReferencesReconciler interface {
+	Reconcile(ctx context.Context, rb eventingv1alpha1.ReconcilableBroker, secretRefs ...corev1.SecretKeySelector) (resources.DeploymentOption, error)
+}
+
+type referencesReconciler struct {
+	tracker         tracker.Interface
+	secretLister    corev1listers.SecretLister
+	configMapLister corev1listers.ConfigMapLister
+}
+
+// NewReferencesReconciler creates a reconciler that tracks the Secrets and
+// ConfigMaps referenced by a broker. The tracker must be the one at the
+// controller implementation, which enqueues the broker when a referenced
+// object changes.
+func NewReferencesReconciler(ctx context.Context,
+	tracker tracker.Interface,
+	secretLister corev1listers.SecretLister,
+	configMapLister corev1listers.ConfigMapLister) ReferencesReconciler {
+	return &referencesReconciler{
+		tracker:         tracker,
+		secretLister:    secretLister,
+		configMapLister: configMapLister,
+	}
+}
+
+// Reconcile tracks the broker's observability ConfigMap and the Secret keys
+// passed as arguments, and returns a Deployment option that annotates the pod
+// template with a hash of their contents.
+func (r *referencesReconciler) Reconcile(ctx context.Context, rb eventingv1alpha1.ReconcilableBroker, secretRefs ...corev1.SecretKeySelector) (resources.DeploymentOption, error) {
+	ns := rb.GetObjectMeta().GetNamespace()
+	h := sha256.New()
+	referenced := false
+
+	for _, ref := range secretRefs {
+		s, err := r.getSecret(ctx, rb, ref.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		v, ok := s.Data[ref.Key]
+		if !ok {
+			rb.GetReconcilableBrokerStatus().MarkReferencesResolvedFailed(ReasonReferenceDoesNotExist,
+				"Secret %q does not contain key %q", ref.Name, ref.Key)
+			return nil, pkgreconciler.NewEvent(corev1.EventTypeWarning, ReasonReferenceDoesNotExist,
+				"Secret %s/%s does not contain key %q", ns, ref.Name, ref.Key)
+		}
+
+		writeHashEntry(h, "Secret", ref.Name, ref.Key, v)
+		referenced = true
+	}
+
+	if obs := rb.GetReconcilableBrokerSpec().Observability; obs != nil && obs.ValueFromConfigMap != "" {
+		cm, err := r.getConfigMap(ctx, rb, obs.ValueFromConfigMap)
+		if err != nil {
+			return nil, err
+		}
+
+		keys := make([]string, 0, len(cm.Data))
+		for k := range cm.Data {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			writeHashEntry(h, "ConfigMap", cm.Name, k, []byte(cm.Data[k]))
+		}
+
+		keys = make([]string, 0, len(cm.BinaryData))
+		for k := range cm.BinaryData {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			writeHashEntry(h, "ConfigMap", cm.Name, k, cm.BinaryData[k])
+		}
+
+		referenced = true
+	}
+
+	rb.GetReconcilableBrokerStatus().MarkReferencesResolved()
+
+	// Brokers without references do not get the annotation, which avoids
+	// rolling out pods that existed before references were tracked.
+	if !referenced {
+		return func(*appsv1.Deployment) {}, nil
+	}
+
+	return resources.DeploymentWithTemplateSpecOptions(
+		resources.PodTemplateSpecWithMetaOptions(
+			resources.MetaAddAnnotation(ReferencesHashAnnotation, hex.EncodeToString(h.Sum(nil))))), nil
+}
+
+func (r *referencesReconciler) getSecret(ctx context.Context, rb eventingv1alpha1.ReconcilableBroker, name string) (*corev1.Secret, error) {
+	ns := rb.GetObjectMeta().GetNamespace()
+
+	if err := r.track(ctx, rb, "Secret", name); err != nil {
+		return nil, err
+	}
+
+	s, err := r.secretLister.Secrets(ns).Get(name)
+	switch {
+	case err == nil:
+		return s, nil
+	case apierrs.IsNotFound(err):
+		rb.GetReconcilableBrokerStatus().MarkReferencesResolvedFailed(ReasonReferenceDoesNotExist,
+			"Secret %q does not exist", name)
+		return nil, pkgreconciler.NewEvent(corev1.EventTypeWarning, ReasonReferenceDoesNotExist,
+			"Secret %s/%s does not exist", ns, name)
+	default:
+		logging.FromContext(ctx).Errorw("Unable to get referenced Secret", zap.String("secret", ns+"/"+name), zap.Error(err))
+		rb.GetReconcilableBrokerStatus().MarkReferencesResolvedFailed(ReasonFailedReferenceGet,
+			"Failed to get Secret %q: %v", name, err)
+		return nil, pkgreconciler.NewEvent(corev1.EventTypeWarning, ReasonFailedReferenceGet,
+			"Failed to get Secret %s/%s: %w", ns, name, err)
+	}
+}
+
+func (r *referencesReconciler) getConfigMap(ctx context.Context, rb eventingv1alpha1.ReconcilableBroker, name string) (*corev1.ConfigMap, error) {
+	ns := rb.GetObjectMeta().GetNamespace()
+
+	if err := r.track(ctx, rb, "ConfigMap", name); err != nil {
+		return nil, err
+	}
+
+	cm, err := r.configMapLister.ConfigMaps(ns).Get(name)
+	switch {
+	case err == nil:
+		return cm, nil
+	case apierrs.IsNotFound(err):
+		rb.GetReconcilableBrokerStatus().MarkReferencesResolvedFailed(ReasonReferenceDoesNotExist,
+			"ConfigMap %q does not exist", name)
+		return nil, pkgreconciler.NewEvent(corev1.EventTypeWarning, ReasonReferenceDoesNotExist,
+			"ConfigMap %s/%s does not exist", ns, name)
+	default:
+		logging.FromContext(ctx).Errorw("Unable to get referenced ConfigMap", zap.String("configmap", ns+"/"+name), zap.Error(err))
+		rb.GetReconcilableBrokerStatus().MarkReferencesResolvedFailed(ReasonFailedReferenceGet,
+			"Failed to get ConfigMap %q: %v", name, err)
+		return nil, pkgreconciler.NewEvent(corev1.EventTypeWarning, ReasonFailedReferenceGet,
+			"Failed to get ConfigMap %s/%s: %w", ns, name, err)
+	}
+}
+
+// track registers the broker as interested in changes of the referenced
+// object, including its creation when it does not exist yet.
+func (r *referencesReconciler) track(ctx context.Context, rb eventingv1alpha1.ReconcilableBroker, kind, name string) error {
+	ns := rb.GetObjectMeta().GetNamespace()
+
+	if err := r.tracker.TrackReference(tracker.Reference{
+		APIVersion: "v1",
+		Kind:       kind,
+		Namespace:  ns,
+		Name:       name,
+	}, rb); err != nil {
+		logging.FromContext(ctx).Errorw("Unable to track referenced object", zap.String("kind", kind), zap.String("name", ns+"/"+name), zap.Error(err))
+		rb.GetReconcilableBrokerStatus().MarkReferencesResolvedFailed(ReasonFailedReferenceTrack,
+			"Failed to track %s %q: %v", kind, name, err)
+		return pkgreconciler.NewEvent(corev1.EventTypeWarning, ReasonFailedReferenceTrack,
+			"Failed to track %s %s/%s: %w", kind, ns, name, err)
+	}
+
+	return nil
+}
+
+// writeHashEntry adds a referenced value to the hash, prefixing it with its
+// origin and length so that entries cannot be confused with each other.
+func writeHashEntry(h hash.Hash, kind, name, key string, value []byte) {
+	fmt.Fprintf(h, "%s/%s/%s:%d:", kind, name, key, len(value))
+	h.Write(value)
+}
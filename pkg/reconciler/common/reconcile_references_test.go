@@ -0,0 +1,140 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	knt "knative.dev/pkg/reconciler/testing"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	tmt "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing"
+	tresources "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing/resources"
+	tmtv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing/v1alpha1"
+)
+
+func TestReconcileReferences(t *testing.T) {
+	const (
+		secretName    = "redis-credentials"
+		configMapName = "observability"
+	)
+
+	secret := func(password string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: tresources.TestNamespace, Name: secretName},
+			Data:       map[string][]byte{"password": []byte(password)},
+		}
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: tresources.TestNamespace, Name: configMapName},
+		Data:       map[string]string{"loglevel.broker": "debug"},
+	}
+	passwordRef := corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+		Key:                  "password",
+	}
+
+	testCases := map[string]struct {
+		objects       []runtime.Object
+		observability string
+		secretRefs    []corev1.SecretKeySelector
+
+		expectedErr        bool
+		expectedAnnotation bool
+	}{
+		"no references": {},
+		"secret reference": {
+			objects:            []runtime.Object{secret("s3cr3t")},
+			secretRefs:         []corev1.SecretKeySelector{passwordRef},
+			expectedAnnotation: true,
+		},
+		"missing secret": {
+			secretRefs:  []corev1.SecretKeySelector{passwordRef},
+			expectedErr: true,
+		},
+		"missing secret key": {
+			objects: []runtime.Object{secret("s3cr3t")},
+			secretRefs: []corev1.SecretKeySelector{{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+				Key:                  "username",
+			}},
+			expectedErr: true,
+		},
+		"observability configmap": {
+			objects:            []runtime.Object{configMap},
+			observability:      configMapName,
+			expectedAnnotation: true,
+		},
+		"missing observability configmap": {
+			observability: configMapName,
+			expectedErr:   true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			b := tmtv1alpha1.NewMemoryBroker(tresources.TestNamespace, tresources.TestName)
+			if tc.observability != "" {
+				b.Spec.Broker.Observability = &eventingv1alpha1.Observability{ValueFromConfigMap: tc.observability}
+			}
+
+			ls := tmt.NewListers(append([]runtime.Object{b}, tc.objects...))
+			r := NewReferencesReconciler(context.Background(), &knt.FakeTracker{},
+				ls.GetSecretLister(), ls.GetConfigMapLister())
+
+			opt, err := r.Reconcile(context.Background(), b, tc.secretRefs...)
+			cond := b.Status.GetCondition(eventingv1alpha1.MemoryBrokerReferencesResolved)
+			require.NotNil(t, cond)
+
+			if tc.expectedErr {
+				assert.Error(t, err)
+				assert.True(t, cond.IsFalse(), "references condition should be false")
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, cond.IsTrue(), "references condition should be true")
+
+			d := &appsv1.Deployment{}
+			opt(d)
+			_, ok := d.Spec.Template.Annotations[ReferencesHashAnnotation]
+			assert.Equal(t, tc.expectedAnnotation, ok)
+		})
+	}
+}
+
+func TestReconcileReferencesHashChanges(t *testing.T) {
+	hashFor := func(password string) string {
+		b := tmtv1alpha1.NewMemoryBroker(tresources.TestNamespace, tresources.TestName)
+		s := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: tresources.TestNamespace, Name: "credentials"},
+			Data:       map[string][]byte{"password": []byte(password)},
+		}
+
+		ls := tmt.NewListers([]runtime.Object{b, s})
+		r := NewReferencesReconciler(context.Background(), &knt.FakeTracker{},
+			ls.GetSecretLister(), ls.GetConfigMapLister())
+
+		opt, err := r.Reconcile(context.Background(), b, corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "credentials"},
+			Key:                  "password",
+		})
+		require.NoError(t, err)
+
+		d := &appsv1.Deployment{}
+		opt(d)
+		return d.Spec.Template.Annotations[ReferencesHashAnnotation]
+	}
+
+	assert.Equal(t, hashFor("one"), hashFor("one"), "hash should be stable for the same contents")
+	assert.NotEqual(t, hashFor("one"), hashFor("two"), "hash should change when contents change")
+}
@@ -39,18 +39,22 @@ type SecretReconciler interface {
 }
 
 type secretReconciler struct {
-	client        kubernetes.Interface
-	secretLister  corev1listers.SecretLister
-	triggerLister eventingv1alpha1listers.TriggerLister
+	client          kubernetes.Interface
+	secretLister    corev1listers.SecretLister
+	triggerLister   eventingv1alpha1listers.TriggerLister
+	brokerLister    eventingv1alpha1listers.BrokerLister
+	namespaceLister corev1listers.NamespaceLister
 }
 
 var _ SecretReconciler = (*secretReconciler)(nil)
 
-func NewSecretReconciler(ctx context.Context, secretLister corev1listers.SecretLister, triggerLister eventingv1alpha1listers.TriggerLister) SecretReconciler {
+func NewSecretReconciler(ctx context.Context, secretLister corev1listers.SecretLister, triggerLister eventingv1alpha1listers.TriggerLister, brokerLister eventingv1alpha1listers.BrokerLister, namespaceLister corev1listers.NamespaceLister) SecretReconciler {
 	return &secretReconciler{
-		client:        k8sclient.Get(ctx),
-		secretLister:  secretLister,
-		triggerLister: triggerLister,
+		client:          k8sclient.Get(ctx),
+		secretLister:    secretLister,
+		triggerLister:   triggerLister,
+		brokerLister:    brokerLister,
+		namespaceLister: namespaceLister,
 	}
 }
 
@@ -110,22 +114,49 @@ func (r *secretReconciler) buildConfigSecret(ctx context.Context, rb eventingv1a
 	meta := rb.GetObjectMeta()
 	ns, name := meta.GetNamespace(), meta.GetName()
 
-	triggers, err := r.triggerLister.Triggers(ns).List(labels.Everything())
+	// Triggers at any namespace might reference the broker.
+	triggers, err := r.triggerLister.List(labels.Everything())
 	if err != nil {
-		logging.FromContext(ctx).Error("Unable to list triggers at namespace", zap.Error(err))
+		logging.FromContext(ctx).Error("Unable to list triggers", zap.Error(err))
 		rb.GetReconcilableBrokerStatus().MarkConfigSecretFailed(ReasonFailedTriggerList, "Failed to list triggers")
 
 		return nil, pkgreconciler.NewEvent(corev1.EventTypeWarning, ReasonFailedTriggerList,
 			"Failed to list triggers: %w", err)
 	}
 
+	// Broker level delivery options are used as defaults for all triggers.
+	bdo := &broker.DeliveryOptions{}
+	if ds := rb.GetReconcilableBrokerSpec().Delivery; ds != nil {
+		bdo = deliveryOptionsFromSpec(ds)
+	}
+	if uri := rb.GetReconcilableBrokerStatus().GetDeadLetterSinkURI(); uri != nil {
+		dls := uri.String()
+		bdo.DeadLetterURL = &dls
+	}
+
 	cfg := &broker.Config{
 		Triggers: make(map[string]broker.Trigger),
 	}
 	for _, t := range triggers {
 		// Generate secret even if the trigger is not ready, as long as one of the URIs for target
 		// or DLS exist.
-		if !t.OwnerRefableMatchesBroker(rb) || (t.Status.TargetURI == nil && t.Status.DeadLetterSinkURI == nil) {
+		if !TriggerMatchesBroker(t, rb, r.brokerLister) || (t.Status.TargetURI == nil && t.Status.DeadLetterSinkURI == nil) {
+			continue
+		}
+
+		// Triggers being deleted are kept until their finalizer is done,
+		// the broker should stop consuming for them right away.
+		if t.DeletionTimestamp != nil {
+			continue
+		}
+
+		allowed, err := IsTriggerNamespaceAllowed(rb, t.Namespace, r.namespaceLister)
+		if err != nil {
+			logging.FromContext(ctx).Error("Unable to check if trigger namespace is allowed",
+				zap.String("trigger", t.Namespace+"/"+t.Name), zap.Error(err))
+			continue
+		}
+		if !allowed {
 			continue
 		}
 
@@ -140,32 +171,21 @@ func (r *secretReconciler) buildConfigSecret(ctx context.Context, rb eventingv1a
 
 		do := &broker.DeliveryOptions{}
 		if t.Spec.Delivery != nil {
-			do.Retry = t.Spec.Delivery.Retry
-			do.BackoffDelay = t.Spec.Delivery.BackoffDelay
-
-			if t.Spec.Delivery.BackoffPolicy != nil {
-				var bop broker.BackoffPolicyType
-				switch *t.Spec.Delivery.BackoffPolicy {
-				case duckv1.BackoffPolicyLinear:
-					bop = broker.BackoffPolicyLinear
-
-				case duckv1.BackoffPolicyExponential:
-					bop = broker.BackoffPolicyLinear
-				}
-				do.BackoffPolicy = &bop
-			}
+			do = deliveryOptionsFromSpec(t.Spec.Delivery)
+		}
 
-			if t.Status.DeadLetterSinkURI != nil {
-				uri := t.Status.DeadLetterSinkURI.String()
-				do.DeadLetterURL = &uri
-			}
+		// The resolved DLS is tracked at the status independently from the
+		// target, and is used whenever available.
+		if t.Status.DeadLetterSinkURI != nil {
+			uri := t.Status.DeadLetterSinkURI.String()
+			do.DeadLetterURL = &uri
 		}
 
 		trg := broker.Trigger{
 			Filters: t.Spec.Filters,
 			Target: broker.Target{
 				URL:             &targetURI,
-				DeliveryOptions: do,
+				DeliveryOptions: mergeDeliveryOptions(bdo, do),
 			},
 		}
 
@@ -187,11 +207,9 @@ func (r *secretReconciler) buildConfigSecret(ctx context.Context, rb eventingv1a
 		}
 
 		// Add Trigger data to config
-		cfg.Triggers[t.Name] = trg
+		cfg.Triggers[t.ConfigKey()] = trg
 	}
 
-	// TODO add user/password
-
 	b, err := yaml.Marshal(cfg)
 	if err != nil {
 		logging.FromContext(ctx).Error("Unable to marshal configuration into YAML", zap.Error(err))
@@ -213,3 +231,55 @@ func (r *secretReconciler) buildConfigSecret(ctx context.Context, rb eventingv1a
 			resources.MetaAddOwner(meta, rb.GetGroupVersionKind())),
 		resources.SecretSetData(ConfigSecretKey, b)), nil
 }
+
+// deliveryOptionsFromSpec translates a Trigger's delivery spec into broker
+// delivery options. Dead letter sinks need to be resolved before being added
+// to the options.
+//
+// Timeout and RetryAfterMax are not supported by the broker configuration
+// and are not rendered.
+func deliveryOptionsFromSpec(ds *duckv1.DeliverySpec) *broker.DeliveryOptions {
+	do := &broker.DeliveryOptions{
+		Retry:        ds.Retry,
+		BackoffDelay: ds.BackoffDelay,
+	}
+
+	if ds.BackoffPolicy != nil {
+		var bop broker.BackoffPolicyType
+		switch *ds.BackoffPolicy {
+		case duckv1.BackoffPolicyLinear:
+			bop = broker.BackoffPolicyLinear
+		case duckv1.BackoffPolicyExponential:
+			bop = broker.BackoffPolicyExponential
+		case eventingv1alpha1.BackoffPolicyConstant:
+			bop = broker.BackoffPolicyConstant
+		default:
+			// Unknown policies are left for the broker to default.
+			return do
+		}
+		do.BackoffPolicy = &bop
+	}
+
+	return do
+}
+
+// mergeDeliveryOptions returns delivery options where each field not informed
+// at overrides is taken from defaults.
+func mergeDeliveryOptions(defaults, overrides *broker.DeliveryOptions) *broker.DeliveryOptions {
+	do := *overrides
+
+	if do.Retry == nil {
+		do.Retry = defaults.Retry
+	}
+	if do.BackoffPolicy == nil {
+		do.BackoffPolicy = defaults.BackoffPolicy
+	}
+	if do.BackoffDelay == nil {
+		do.BackoffDelay = defaults.BackoffDelay
+	}
+	if do.DeadLetterURL == nil {
+		do.DeadLetterURL = defaults.DeadLetterURL
+	}
+
+	return &do
+}
@@ -0,0 +1,266 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	duckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/ptr"
+
+	"github.com/triggermesh/brokers/pkg/config/broker"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	tmt "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing"
+	tresources "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing/resources"
+	tmtv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing/v1alpha1"
+)
+
+func TestDeliveryOptionsFromSpec(t *testing.T) {
+	policy := func(p duckv1.BackoffPolicyType) *duckv1.BackoffPolicyType { return &p }
+	brokerPolicy := func(p broker.BackoffPolicyType) *broker.BackoffPolicyType { return &p }
+
+	testCases := map[string]struct {
+		spec     duckv1.DeliverySpec
+		expected broker.DeliveryOptions
+	}{
+		"no backoff policy": {
+			spec: duckv1.DeliverySpec{
+				Retry: ptr.Int32(3),
+			},
+			expected: broker.DeliveryOptions{
+				Retry: ptr.Int32(3),
+			},
+		},
+		"linear backoff": {
+			spec: duckv1.DeliverySpec{
+				Retry:         ptr.Int32(3),
+				BackoffPolicy: policy(duckv1.BackoffPolicyLinear),
+				BackoffDelay:  ptr.String("PT1S"),
+			},
+			expected: broker.DeliveryOptions{
+				Retry:         ptr.Int32(3),
+				BackoffPolicy: brokerPolicy(broker.BackoffPolicyLinear),
+				BackoffDelay:  ptr.String("PT1S"),
+			},
+		},
+		"exponential backoff": {
+			spec: duckv1.DeliverySpec{
+				BackoffPolicy: policy(duckv1.BackoffPolicyExponential),
+				BackoffDelay:  ptr.String("PT0.5S"),
+			},
+			expected: broker.DeliveryOptions{
+				BackoffPolicy: brokerPolicy(broker.BackoffPolicyExponential),
+				BackoffDelay:  ptr.String("PT0.5S"),
+			},
+		},
+		"constant backoff": {
+			spec: duckv1.DeliverySpec{
+				BackoffPolicy: policy(eventingv1alpha1.BackoffPolicyConstant),
+			},
+			expected: broker.DeliveryOptions{
+				BackoffPolicy: brokerPolicy(broker.BackoffPolicyConstant),
+			},
+		},
+		"unknown backoff": {
+			spec: duckv1.DeliverySpec{
+				BackoffPolicy: policy("fibonacci"),
+			},
+			expected: broker.DeliveryOptions{},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, *deliveryOptionsFromSpec(&tc.spec))
+		})
+	}
+}
+
+func TestBuildConfigSecretDeliveryOptions(t *testing.T) {
+	const (
+		targetURI = "http://target.ns.svc.cluster.local"
+		dlsURI    = "http://dls.ns.svc.cluster.local"
+	)
+
+	brokerDelivery := &duckv1.DeliverySpec{
+		Retry:        ptr.Int32(5),
+		BackoffDelay: ptr.String("PT2S"),
+	}
+	const brokerDLSURI = "http://broker-dls.ns.svc.cluster.local"
+
+	testCases := map[string]struct {
+		trigger        *eventingv1alpha1.Trigger
+		brokerDelivery *duckv1.DeliverySpec
+		brokerDLS      string
+		expected       *broker.Target
+	}{
+		"not resolved": {
+			trigger: tmtv1alpha1.NewTrigger(tresources.TestNamespace, "trigger", tresources.TestName),
+		},
+		"target and DLS without delivery spec": {
+			trigger: tmtv1alpha1.NewTrigger(tresources.TestNamespace, "trigger", tresources.TestName,
+				tmtv1alpha1.TriggerWithStatusTargetURI(targetURI),
+				tmtv1alpha1.TriggerWithStatusDeadLetterSinkURI(dlsURI)),
+			expected: &broker.Target{
+				URL: ptr.String(targetURI),
+				DeliveryOptions: &broker.DeliveryOptions{
+					DeadLetterURL: ptr.String(dlsURI),
+				},
+			},
+		},
+		"DLS only": {
+			trigger: tmtv1alpha1.NewTrigger(tresources.TestNamespace, "trigger", tresources.TestName,
+				tmtv1alpha1.TriggerWithDelivery(&duckv1.DeliverySpec{Retry: ptr.Int32(2)}),
+				tmtv1alpha1.TriggerWithStatusDeadLetterSinkURI(dlsURI)),
+			expected: &broker.Target{
+				URL: ptr.String(""),
+				DeliveryOptions: &broker.DeliveryOptions{
+					Retry:         ptr.Int32(2),
+					DeadLetterURL: ptr.String(dlsURI),
+				},
+			},
+		},
+		"broker defaults": {
+			trigger: tmtv1alpha1.NewTrigger(tresources.TestNamespace, "trigger", tresources.TestName,
+				tmtv1alpha1.TriggerWithStatusTargetURI(targetURI)),
+			brokerDelivery: brokerDelivery,
+			brokerDLS:      brokerDLSURI,
+			expected: &broker.Target{
+				URL: ptr.String(targetURI),
+				DeliveryOptions: &broker.DeliveryOptions{
+					Retry:         ptr.Int32(5),
+					BackoffDelay:  ptr.String("PT2S"),
+					DeadLetterURL: ptr.String(brokerDLSURI),
+				},
+			},
+		},
+		"trigger overrides broker defaults": {
+			trigger: tmtv1alpha1.NewTrigger(tresources.TestNamespace, "trigger", tresources.TestName,
+				tmtv1alpha1.TriggerWithDelivery(&duckv1.DeliverySpec{Retry: ptr.Int32(1)}),
+				tmtv1alpha1.TriggerWithStatusTargetURI(targetURI),
+				tmtv1alpha1.TriggerWithStatusDeadLetterSinkURI(dlsURI)),
+			brokerDelivery: brokerDelivery,
+			brokerDLS:      brokerDLSURI,
+			expected: &broker.Target{
+				URL: ptr.String(targetURI),
+				DeliveryOptions: &broker.DeliveryOptions{
+					Retry:         ptr.Int32(1),
+					BackoffDelay:  ptr.String("PT2S"),
+					DeadLetterURL: ptr.String(dlsURI),
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			b := tmtv1alpha1.NewMemoryBroker(tresources.TestNamespace, tresources.TestName)
+			b.Spec.Broker.Delivery = tc.brokerDelivery
+			if tc.brokerDLS != "" {
+				uri, err := apis.ParseURL(tc.brokerDLS)
+				require.NoError(t, err)
+				b.Status.MarkDeadLetterSinkResolvedSucceeded(uri)
+			}
+
+			ls := tmt.NewListers([]runtime.Object{b, tc.trigger})
+			r := &secretReconciler{
+				triggerLister: ls.GetTriggerLister(),
+			}
+
+			s, err := r.buildConfigSecret(context.Background(), b)
+			require.NoError(t, err)
+
+			cfg := &broker.Config{}
+			require.NoError(t, yaml.Unmarshal(s.Data[ConfigSecretKey], cfg))
+
+			trg, ok := cfg.Triggers[tc.trigger.Name]
+			if tc.expected == nil {
+				assert.False(t, ok, "trigger should not be rendered")
+				return
+			}
+
+			require.True(t, ok, "trigger should be rendered")
+			assert.Equal(t, *tc.expected, trg.Target)
+		})
+	}
+}
+
+func TestBuildConfigSecretTriggerNamespaces(t *testing.T) {
+	const (
+		otherNamespace = "other-namespace"
+		targetURI      = "http://target.ns.svc.cluster.local"
+	)
+
+	testCases := map[string]struct {
+		selector         *metav1.LabelSelector
+		namespaceLabels  map[string]string
+		expectedRendered bool
+	}{
+		"no selector": {
+			namespaceLabels:  map[string]string{"team": "a"},
+			expectedRendered: false,
+		},
+		"empty selector": {
+			selector:         &metav1.LabelSelector{},
+			expectedRendered: true,
+		},
+		"matching selector": {
+			selector:         &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+			namespaceLabels:  map[string]string{"team": "a"},
+			expectedRendered: true,
+		},
+		"not matching selector": {
+			selector:         &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+			namespaceLabels:  map[string]string{"team": "b"},
+			expectedRendered: false,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			b := tmtv1alpha1.NewMemoryBroker(tresources.TestNamespace, tresources.TestName)
+			b.Spec.Broker.TriggerNamespaceSelector = tc.selector
+
+			ns := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   otherNamespace,
+					Labels: tc.namespaceLabels,
+				},
+			}
+			local := tmtv1alpha1.NewTrigger(tresources.TestNamespace, "trigger", tresources.TestName,
+				tmtv1alpha1.TriggerWithStatusTargetURI(targetURI))
+			remote := tmtv1alpha1.NewTrigger(otherNamespace, "trigger", tresources.TestName,
+				tmtv1alpha1.TriggerWithBrokerNamespace(tresources.TestNamespace),
+				tmtv1alpha1.TriggerWithStatusTargetURI(targetURI))
+
+			ls := tmt.NewListers([]runtime.Object{b, ns, local, remote})
+			r := &secretReconciler{
+				triggerLister:   ls.GetTriggerLister(),
+				namespaceLister: ls.GetNamespaceLister(),
+			}
+
+			s, err := r.buildConfigSecret(context.Background(), b)
+			require.NoError(t, err)
+
+			cfg := &broker.Config{}
+			require.NoError(t, yaml.Unmarshal(s.Data[ConfigSecretKey], cfg))
+
+			_, ok := cfg.Triggers["trigger"]
+			assert.True(t, ok, "same namespace trigger should always be rendered")
+
+			_, ok = cfg.Triggers[otherNamespace+"/trigger"]
+			assert.Equal(t, tc.expectedRendered, ok)
+		})
+	}
+}
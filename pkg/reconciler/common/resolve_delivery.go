@@ -0,0 +1,45 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/resolver"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+)
+
+// ResolveBrokerDeadLetterSink resolves the broker level dead letter sink and
+// informs it at the broker status, where it is picked up when rendering the
+// broker configuration.
+func ResolveBrokerDeadLetterSink(ctx context.Context, uriResolver *resolver.URIResolver, rb eventingv1alpha1.ReconcilableBroker) pkgreconciler.Event {
+	ds := rb.GetReconcilableBrokerSpec().Delivery
+	if ds == nil || ds.DeadLetterSink == nil {
+		rb.GetReconcilableBrokerStatus().MarkDeadLetterSinkNotConfigured()
+		return nil
+	}
+
+	dlsURI, err := uriResolver.URIFromDestinationV1(ctx, *ds.DeadLetterSink, rb)
+	if err != nil {
+		logging.FromContext(ctx).Errorw("Unable to get the broker's dead letter sink URI", zap.Error(err))
+
+		reason := ReasonFailedResolveDeadLetterSink
+		if apierrs.IsNotFound(err) {
+			reason = ReasonDeadLetterSinkDoesNotExist
+		}
+		rb.GetReconcilableBrokerStatus().MarkDeadLetterSinkResolvedFailed(reason, "Unable to get the dead letter sink's URI: %v", err)
+		return pkgreconciler.NewEvent(corev1.EventTypeWarning, reason,
+			"Failed to get broker's dead letter sink URI: %w", err)
+	}
+
+	rb.GetReconcilableBrokerStatus().MarkDeadLetterSinkResolvedSucceeded(dlsURI)
+	return nil
+}
@@ -0,0 +1,25 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	eventinglisters "github.com/triggermesh/triggermesh-core/pkg/client/generated/listers/eventing/v1alpha1"
+)
+
+// TriggerMatchesBroker returns whether the Trigger references the broker. When
+// the Trigger references a class based Broker, the Broker is retrieved to make
+// sure that the broker is of its current class and controlled by it.
+func TriggerMatchesBroker(t *eventingv1alpha1.Trigger, rb eventingv1alpha1.ReconcilableBroker, brLister eventinglisters.BrokerLister) bool {
+	if !t.ReferencesClassBroker() {
+		return t.OwnerRefableMatchesBroker(rb)
+	}
+
+	b, err := brLister.Brokers(t.BrokerNamespace()).Get(t.Spec.Broker.Name)
+	if err != nil {
+		return false
+	}
+
+	return t.OwnerRefableMatchesClassBroker(b, rb)
+}
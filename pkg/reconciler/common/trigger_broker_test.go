@@ -0,0 +1,69 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	"github.com/triggermesh/triggermesh-core/pkg/reconciler/resources"
+	tmt "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing"
+	tresources "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing/resources"
+	tmtv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing/v1alpha1"
+)
+
+func TestTriggerMatchesBroker(t *testing.T) {
+	b := tmtv1alpha1.NewBroker(tresources.TestNamespace, tresources.TestName,
+		tmtv1alpha1.BrokerWithClass(eventingv1alpha1.BrokerClassRedis))
+	ownedByBroker := resources.MetaAddOwner(b, b.GetGroupVersionKind())
+
+	served := tmtv1alpha1.NewRedisBroker(tresources.TestNamespace, tresources.TestName,
+		tmtv1alpha1.RedisBrokerWithMetaOptions(ownedByBroker))
+	stale := tmtv1alpha1.NewMemoryBroker(tresources.TestNamespace, tresources.TestName,
+		tmtv1alpha1.MemoryBrokerWithMetaOptions(ownedByBroker))
+
+	classTrigger := tmtv1alpha1.NewTrigger(tresources.TestNamespace, "trigger", tresources.TestName)
+	classTrigger.Spec.Broker.Kind = "Broker"
+
+	testCases := map[string]struct {
+		trigger  *eventingv1alpha1.Trigger
+		broker   eventingv1alpha1.ReconcilableBroker
+		objects  []runtime.Object
+		expected bool
+	}{
+		"concrete broker kind": {
+			trigger:  tmtv1alpha1.NewTrigger(tresources.TestNamespace, "trigger", tresources.TestName),
+			broker:   stale,
+			expected: true,
+		},
+		"broker of the Broker class": {
+			trigger:  classTrigger,
+			broker:   served,
+			objects:  []runtime.Object{b},
+			expected: true,
+		},
+		"broker of a previous Broker class": {
+			trigger:  classTrigger,
+			broker:   stale,
+			objects:  []runtime.Object{b},
+			expected: false,
+		},
+		"Broker not found": {
+			trigger:  classTrigger,
+			broker:   served,
+			expected: false,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ls := tmt.NewListers(tc.objects)
+			assert.Equal(t, tc.expected, TriggerMatchesBroker(tc.trigger, tc.broker, ls.GetBrokerLister()))
+		})
+	}
+}
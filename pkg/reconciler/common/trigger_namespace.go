@@ -0,0 +1,41 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+)
+
+// IsTriggerNamespaceAllowed returns whether Triggers at the namespace are
+// allowed to subscribe to the broker. Triggers at the broker's namespace are
+// always allowed, other namespaces need to match the broker's
+// triggerNamespaceSelector.
+func IsTriggerNamespaceAllowed(rb eventingv1alpha1.ReconcilableBroker, namespace string, nsLister corev1listers.NamespaceLister) (bool, error) {
+	if namespace == rb.GetObjectMeta().GetNamespace() {
+		return true, nil
+	}
+
+	ls := rb.GetReconcilableBrokerSpec().TriggerNamespaceSelector
+	if ls == nil {
+		return false, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(ls)
+	if err != nil {
+		return false, fmt.Errorf("invalid trigger namespace selector: %w", err)
+	}
+
+	ns, err := nsLister.Get(namespace)
+	if err != nil {
+		return false, err
+	}
+
+	return selector.Matches(labels.Set(ns.Labels)), nil
+}
@@ -0,0 +1,220 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkabroker
+
+import (
+	"context"
+
+	"github.com/kelseyhightower/envconfig"
+	"go.uber.org/zap"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+
+	"knative.dev/pkg/client/injection/kube/informers/apps/v1/deployment"
+	hpainformer "knative.dev/pkg/client/injection/kube/informers/autoscaling/v2/horizontalpodautoscaler"
+	"knative.dev/pkg/client/injection/kube/informers/core/v1/configmap"
+	endpointsinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/endpoints"
+	nsinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/namespace"
+	"knative.dev/pkg/client/injection/kube/informers/core/v1/secret"
+	"knative.dev/pkg/client/injection/kube/informers/core/v1/service"
+	"knative.dev/pkg/client/injection/kube/informers/core/v1/serviceaccount"
+	ingressinformer "knative.dev/pkg/client/injection/kube/informers/networking/v1/ingress"
+	rolebindingsinformer "knative.dev/pkg/client/injection/kube/informers/rbac/v1/rolebinding"
+	cmw "knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/resolver"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	brinformer "github.com/triggermesh/triggermesh-core/pkg/client/generated/injection/informers/eventing/v1alpha1/broker"
+	rbinformer "github.com/triggermesh/triggermesh-core/pkg/client/generated/injection/informers/eventing/v1alpha1/kafkabroker"
+	trginformer "github.com/triggermesh/triggermesh-core/pkg/client/generated/injection/informers/eventing/v1alpha1/trigger"
+	rbreconciler "github.com/triggermesh/triggermesh-core/pkg/client/generated/injection/reconciler/eventing/v1alpha1/kafkabroker"
+	"github.com/triggermesh/triggermesh-core/pkg/reconciler/common"
+	"github.com/triggermesh/triggermesh-core/pkg/reconciler/resources"
+)
+
+// envConfig will be used to extract the required environment variables using
+// github.com/kelseyhightower/envconfig. If this configuration cannot be extracted, then
+// NewController will panic.
+type envConfig struct {
+	BrokerImage           string `envconfig:"KAFKABROKER_BROKER_IMAGE" required:"true"`
+	BrokerImagePullPolicy string `envconfig:"KAFKABROKER_BROKER_IMAGE_PULL_POLICY" default:"IfNotPresent"`
+}
+
+// NewController initializes the controller and is called by the generated code
+// Registers event handlers to enqueue events
+func NewController(
+	ctx context.Context,
+	cmw cmw.Watcher,
+) *controller.Impl {
+
+	env := &envConfig{}
+	if err := envconfig.Process("", env); err != nil {
+		logging.FromContext(ctx).Panicf("unable to process KafkaBroker's required environment variables: %v", err)
+	}
+
+	rbInformer := rbinformer.Get(ctx)
+	trgInformer := trginformer.Get(ctx)
+	brInformer := brinformer.Get(ctx)
+	secretInformer := secret.Get(ctx)
+	configMapInformer := configmap.Get(ctx)
+	namespaceInformer := nsinformer.Get(ctx)
+	deploymentInformer := deployment.Get(ctx)
+	hpaInformer := hpainformer.Get(ctx)
+	serviceInformer := service.Get(ctx)
+	endpointsInformer := endpointsinformer.Get(ctx)
+	ingressInformer := ingressinformer.Get(ctx)
+	serviceAccountInformer := serviceaccount.Get(ctx)
+	roleBindingsInformer := rolebindingsinformer.Get(ctx)
+
+	r := &reconciler{
+		secretReconciler:    common.NewSecretReconciler(ctx, secretInformer.Lister(), trgInformer.Lister(), brInformer.Lister(), namespaceInformer.Lister()),
+		configMapReconciler: common.NewConfigMapReconciler(ctx, configMapInformer.Lister()),
+		saReconciler:        common.NewServiceAccountReconciler(ctx, serviceAccountInformer.Lister(), roleBindingsInformer.Lister()),
+	}
+
+	impl := rbreconciler.NewImpl(ctx, r)
+	r.uriResolver = resolver.NewURIResolverFromTracker(ctx, impl.Tracker)
+	r.referencesReconciler = common.NewReferencesReconciler(ctx, impl.Tracker, secretInformer.Lister(), configMapInformer.Lister())
+	r.brokerReconciler = common.NewBrokerReconciler(ctx, impl.Tracker, deploymentInformer.Lister(), hpaInformer.Lister(), serviceInformer.Lister(), endpointsInformer.Lister(), ingressInformer.Lister(),
+		env.BrokerImage, corev1.PullPolicy(env.BrokerImagePullPolicy))
+
+	rb := &eventingv1alpha1.KafkaBroker{}
+	gvk := rb.GetGroupVersionKind()
+
+	rbInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+
+	secretInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterController(rb),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	// Secrets and ConfigMaps referenced by brokers are notified to the tracker.
+	secretInformer.Informer().AddEventHandler(controller.HandleAll(
+		controller.EnsureTypeMeta(impl.Tracker.OnChanged, corev1.SchemeGroupVersion.WithKind("Secret"))))
+	configMapInformer.Informer().AddEventHandler(controller.HandleAll(
+		controller.EnsureTypeMeta(impl.Tracker.OnChanged, corev1.SchemeGroupVersion.WithKind("ConfigMap"))))
+
+	deploymentInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterController(rb),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+	hpaInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterController(rb),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+	serviceInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterController(rb),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+	ingressInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterController(rb),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+	endpointsInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			ep, ok := obj.(*corev1.Endpoints)
+			if !ok || ep.Labels != nil || ep.Labels[resources.AppNameLabel] == common.AppAnnotationValue(rb) {
+				return false
+			}
+
+			return true
+		},
+		Handler: controller.HandleAll(func(obj interface{}) {
+			ep, ok := obj.(*corev1.Endpoints)
+			if !ok {
+				return
+			}
+
+			svc, err := serviceInformer.Lister().Services(ep.Namespace).Get(ep.Name)
+			if err != nil {
+				// no matter the error, if we cannot retrieve the service we cannot
+				// read the owner and enqueue the key.
+				return
+			}
+
+			impl.EnqueueControllerOf(svc)
+		}),
+	})
+	serviceAccountInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterController(rb),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+	roleBindingsInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterController(rb),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	// Filter Triggers that reference a Kafka broker.
+	filterTriggerForKafkaBroker := func(obj interface{}) bool {
+		t, ok := obj.(*eventingv1alpha1.Trigger)
+		if !ok {
+			return false
+		}
+
+		// Triggers created before the webhook was deployed might not be defaulted.
+		if !(t.Spec.Broker.Group == gvk.Group || t.Spec.Broker.Group == "") ||
+			(t.Spec.Broker.Kind != gvk.Kind && !t.ReferencesClassBroker()) {
+			return false
+		}
+
+		b, err := rbInformer.Lister().KafkaBrokers(t.BrokerNamespace()).Get(t.Spec.Broker.Name)
+		switch {
+		case err == nil:
+			// Class based Brokers are served by a broker they control.
+			return t.Spec.Broker.Kind == gvk.Kind || common.TriggerMatchesBroker(t, b, brInformer.Lister())
+		case !apierrs.IsNotFound(err):
+			logging.FromContext(ctx).Error("Unable to get Kafka Broker", zap.Any("broker", t.Spec.Broker), zap.Error(err))
+		}
+
+		return false
+	}
+
+	enqueueFromTrigger := func(obj interface{}) {
+		t, ok := obj.(*eventingv1alpha1.Trigger)
+		if !ok {
+			return
+		}
+
+		impl.EnqueueKey(types.NamespacedName{
+			Name:      t.Spec.Broker.Name,
+			Namespace: t.BrokerNamespace(),
+		})
+	}
+
+	trgInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: filterTriggerForKafkaBroker,
+		Handler:    controller.HandleAll(enqueueFromTrigger),
+	})
+
+	// Namespace labels decide whether their Triggers are allowed to subscribe
+	// to brokers at other namespaces.
+	enqueueFromNamespace := func(obj interface{}) {
+		ns, ok := obj.(*corev1.Namespace)
+		if !ok {
+			return
+		}
+
+		tl, err := trgInformer.Lister().Triggers(ns.Name).List(labels.Everything())
+		if err != nil {
+			logging.FromContext(ctx).Error("Unable to list Triggers", zap.String("namespace", ns.Name), zap.Error(err))
+			return
+		}
+
+		for _, t := range tl {
+			if t.BrokerNamespace() != ns.Name && filterTriggerForKafkaBroker(t) {
+				enqueueFromTrigger(t)
+			}
+		}
+	}
+
+	namespaceInformer.Informer().AddEventHandler(controller.HandleAll(enqueueFromNamespace))
+
+	return impl
+}
@@ -0,0 +1,163 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkabroker
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"knative.dev/pkg/logging"
+	knreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/resolver"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	"github.com/triggermesh/triggermesh-core/pkg/reconciler/common"
+	"github.com/triggermesh/triggermesh-core/pkg/reconciler/resources"
+)
+
+const (
+	kafkaKeyTabVolumeName         = "kafka-gssapi-keytab"
+	kafkaKeyTabPath               = "/opt/kafka/gssapi/keytab"
+	kafkaKeyTabFile               = "user.keytab"
+	kafkaKerberosConfigVolumeName = "kafka-gssapi-krb5"
+	kafkaKerberosConfigPath       = "/opt/kafka/gssapi/krb5"
+	kafkaKerberosConfigFile       = "krb5.conf"
+)
+
+type reconciler struct {
+	secretReconciler    common.SecretReconciler
+	configMapReconciler common.ConfigMapReconciler
+	saReconciler        common.ServiceAccountReconciler
+	brokerReconciler    common.BrokerReconciler
+
+	// referencesReconciler is set after the controller implementation
+	// is created, since it depends on its tracker.
+	referencesReconciler common.ReferencesReconciler
+
+	uriResolver *resolver.URIResolver
+}
+
+// options that set Broker environment variables specific for the KafkaBroker.
+func kafkaDeploymentOption(kb *eventingv1alpha1.KafkaBroker) resources.DeploymentOption {
+	return func(d *appsv1.Deployment) {
+		// Make sure the broker container exists before modifying it.
+		if len(d.Spec.Template.Spec.Containers) == 0 {
+			// Unexpected path.
+			panic("The Broker Deployment to be reconciled has no containers in it.")
+		}
+
+		c := &d.Spec.Template.Spec.Containers[0]
+		k := kb.Spec.Kafka
+
+		resources.ContainerAddEnvFromValue("KAFKA_ADDRESSES", strings.Join(k.BootstrapServers, ","))(c)
+		resources.ContainerAddEnvFromValue("KAFKA_TOPIC", kafkaTopicName(kb))(c)
+
+		if k.EnableTrackingID != nil && *k.EnableTrackingID {
+			resources.ContainerAddEnvFromValue("KAFKA_TRACKING_ID_ENABLED", "true")(c)
+		}
+
+		if k.SASL != nil && k.SASL.GSSAPI != nil {
+			gss := k.SASL.GSSAPI
+
+			resources.ContainerAddEnvFromValue("KAFKA_GSSAPI_SERVICE_NAME", gss.ServiceName)(c)
+			resources.ContainerAddEnvFromValue("KAFKA_GSSAPI_REALM", gss.Realm)(c)
+			resources.ContainerAddEnvFromValue("KAFKA_GSSAPI_PRINCIPAL", gss.Principal)(c)
+
+			// The broker reads the keytab and Kerberos configuration from files.
+			resources.ContainerAddEnvFromValue("KAFKA_GSSAPI_KEYTAB_PATH",
+				filepath.Join(kafkaKeyTabPath, kafkaKeyTabFile))(c)
+			resources.ContainerAddVolumeMount(
+				resources.NewVolumeMount(kafkaKeyTabVolumeName, kafkaKeyTabPath,
+					resources.VolumeMountWithReadOnlyOption(true)))(c)
+			resources.PodSpecAddVolume(
+				resources.NewVolume(kafkaKeyTabVolumeName,
+					resources.VolumeFromSecretOption(gss.KeyTab.SecretKeyRef.Name, gss.KeyTab.SecretKeyRef.Key, kafkaKeyTabFile)))(&d.Spec.Template.Spec)
+
+			resources.ContainerAddEnvFromValue("KAFKA_GSSAPI_KERBEROS_CONFIG_PATH",
+				filepath.Join(kafkaKerberosConfigPath, kafkaKerberosConfigFile))(c)
+			resources.ContainerAddVolumeMount(
+				resources.NewVolumeMount(kafkaKerberosConfigVolumeName, kafkaKerberosConfigPath,
+					resources.VolumeMountWithReadOnlyOption(true)))(c)
+			resources.PodSpecAddVolume(
+				resources.NewVolume(kafkaKerberosConfigVolumeName,
+					resources.VolumeFromSecretOption(gss.KerberosConfig.SecretKeyRef.Name, gss.KerberosConfig.SecretKeyRef.Key, kafkaKerberosConfigFile)))(&d.Spec.Template.Spec)
+		}
+	}
+}
+
+// kafkaTopicName returns the Kafka topic used by the broker.
+func kafkaTopicName(kb *eventingv1alpha1.KafkaBroker) string {
+	if kb.Spec.Kafka.Topic != nil && *kb.Spec.Kafka.Topic != "" {
+		return *kb.Spec.Kafka.Topic
+	}
+	return kb.Namespace + "." + kb.Name
+}
+
+// kafkaSecretReferences returns the Secret keys referenced from the Kafka
+// authentication parameters.
+func kafkaSecretReferences(kb *eventingv1alpha1.KafkaBroker) []corev1.SecretKeySelector {
+	if kb.Spec.Kafka.SASL == nil || kb.Spec.Kafka.SASL.GSSAPI == nil {
+		return nil
+	}
+
+	gss := kb.Spec.Kafka.SASL.GSSAPI
+	return []corev1.SecretKeySelector{
+		gss.KeyTab.SecretKeyRef,
+		gss.KerberosConfig.SecretKeyRef,
+	}
+}
+
+func (r *reconciler) ReconcileKind(ctx context.Context, kb *eventingv1alpha1.KafkaBroker) knreconciler.Event {
+	logging.FromContext(ctx).Infow("Reconciling", zap.Any("KafkaBroker", *kb))
+
+	// Brokers created before the webhook was deployed might not be defaulted.
+	kb.SetDefaults(ctx)
+
+	// Resolve the broker level DLS before rendering the configuration.
+	if err := common.ResolveBrokerDeadLetterSink(ctx, r.uriResolver, kb); err != nil {
+		return err
+	}
+
+	// Iterate triggers and create secret.
+	secret, err := r.secretReconciler.Reconcile(ctx, kb)
+	if err != nil {
+		return err
+	}
+
+	// Make sure the ConfigMap exists.
+	configMap, err := r.configMapReconciler.Reconcile(ctx, kb)
+	if err != nil {
+		return err
+	}
+
+	// Make sure the Broker service account and roles exists.
+	sa, _, err := r.saReconciler.Reconcile(ctx, kb)
+	if err != nil {
+		return err
+	}
+
+	// Track referenced objects so that changes roll out the Broker pods.
+	refsOption, err := r.referencesReconciler.Reconcile(ctx, kb, kafkaSecretReferences(kb)...)
+	if err != nil {
+		return err
+	}
+
+	// Make sure the Broker deployment exists.
+	_, brokerSvc, err := r.brokerReconciler.Reconcile(ctx, kb, sa, secret, configMap, kafkaDeploymentOption(kb), refsOption)
+	if err != nil {
+		return err
+	}
+
+	// Set addresses to the Broker service.
+	address, addresses := common.BrokerAddresses(brokerSvc)
+	kb.Status.SetAddress(address)
+	kb.Status.Addresses = addresses
+
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package kafkabroker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/ptr"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	"github.com/triggermesh/triggermesh-core/pkg/reconciler/resources"
+	tresources "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing/resources"
+	tmtv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing/v1alpha1"
+)
+
+func TestKafkaDeploymentOption(t *testing.T) {
+	secretValue := func(key string) eventingv1alpha1.SecretValueFromSource {
+		return eventingv1alpha1.SecretValueFromSource{
+			SecretKeyRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "kerberos"},
+				Key:                  key,
+			},
+		}
+	}
+
+	testCases := map[string]struct {
+		kafka eventingv1alpha1.Kafka
+
+		expectedEnv     []corev1.EnvVar
+		expectedVolumes []string
+	}{
+		"default topic": {
+			kafka: eventingv1alpha1.Kafka{
+				BootstrapServers: []string{"kafka-0:9092", "kafka-1:9092"},
+			},
+			expectedEnv: []corev1.EnvVar{
+				{Name: "KAFKA_ADDRESSES", Value: "kafka-0:9092,kafka-1:9092"},
+				{Name: "KAFKA_TOPIC", Value: tresources.TestNamespace + "." + tresources.TestName},
+			},
+		},
+		"custom topic and tracking": {
+			kafka: eventingv1alpha1.Kafka{
+				BootstrapServers: []string{"kafka-0:9092"},
+				Topic:            ptr.String("events"),
+				EnableTrackingID: ptr.Bool(true),
+			},
+			expectedEnv: []corev1.EnvVar{
+				{Name: "KAFKA_ADDRESSES", Value: "kafka-0:9092"},
+				{Name: "KAFKA_TOPIC", Value: "events"},
+				{Name: "KAFKA_TRACKING_ID_ENABLED", Value: "true"},
+			},
+		},
+		"gssapi": {
+			kafka: eventingv1alpha1.Kafka{
+				BootstrapServers: []string{"kafka-0:9092"},
+				SASL: &eventingv1alpha1.KafkaSASL{GSSAPI: &eventingv1alpha1.KafkaGSSAPI{
+					ServiceName:    "kafka",
+					Realm:          "EXAMPLE.COM",
+					Principal:      "broker",
+					KeyTab:         secretValue("keytab"),
+					KerberosConfig: secretValue("krb5.conf"),
+				}},
+			},
+			expectedEnv: []corev1.EnvVar{
+				{Name: "KAFKA_ADDRESSES", Value: "kafka-0:9092"},
+				{Name: "KAFKA_TOPIC", Value: tresources.TestNamespace + "." + tresources.TestName},
+				{Name: "KAFKA_GSSAPI_SERVICE_NAME", Value: "kafka"},
+				{Name: "KAFKA_GSSAPI_REALM", Value: "EXAMPLE.COM"},
+				{Name: "KAFKA_GSSAPI_PRINCIPAL", Value: "broker"},
+				{Name: "KAFKA_GSSAPI_KEYTAB_PATH", Value: "/opt/kafka/gssapi/keytab/user.keytab"},
+				{Name: "KAFKA_GSSAPI_KERBEROS_CONFIG_PATH", Value: "/opt/kafka/gssapi/krb5/krb5.conf"},
+			},
+			expectedVolumes: []string{kafkaKeyTabVolumeName, kafkaKerberosConfigVolumeName},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			kb := tmtv1alpha1.NewKafkaBroker(tresources.TestNamespace, tresources.TestName,
+				tmtv1alpha1.KafkaBrokerWithKafka(tc.kafka))
+
+			d := resources.NewDeployment(tresources.TestNamespace, tresources.TestName,
+				resources.DeploymentWithTemplateSpecOptions(
+					resources.PodTemplateSpecWithPodSpecOptions(
+						resources.PodSpecAddContainer(resources.NewContainer("broker", tresources.TestBrokerImage)))),
+				kafkaDeploymentOption(kb))
+
+			assert.Equal(t, tc.expectedEnv, d.Spec.Template.Spec.Containers[0].Env)
+
+			var volumes []string
+			for _, v := range d.Spec.Template.Spec.Volumes {
+				volumes = append(volumes, v.Name)
+			}
+			assert.Equal(t, tc.expectedVolumes, volumes)
+			assert.Len(t, d.Spec.Template.Spec.Containers[0].VolumeMounts, len(tc.expectedVolumes))
+		})
+	}
+}
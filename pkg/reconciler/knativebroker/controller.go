@@ -0,0 +1,70 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package knativebroker
+
+import (
+	"context"
+
+	"k8s.io/client-go/tools/cache"
+
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	cmw "knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	pkgreconciler "knative.dev/pkg/reconciler"
+
+	eventingclient "github.com/triggermesh/triggermesh-core/pkg/client/generated/injection/client"
+	mbinformer "github.com/triggermesh/triggermesh-core/pkg/client/generated/injection/informers/eventing/v1alpha1/memorybroker"
+	rbinformer "github.com/triggermesh/triggermesh-core/pkg/client/generated/injection/informers/eventing/v1alpha1/redisbroker"
+	kbinformer "github.com/triggermesh/triggermesh-core/pkg/client/generated/knative/injection/informers/eventing/v1/broker"
+	kbreconciler "github.com/triggermesh/triggermesh-core/pkg/client/generated/knative/injection/reconciler/eventing/v1/broker"
+)
+
+// NewRedisBrokerController initializes the controller for Knative Brokers of
+// the RedisBroker class.
+func NewRedisBrokerController(
+	ctx context.Context,
+	cmw cmw.Watcher,
+) *controller.Impl {
+	return newController(ctx, RedisBrokerClass, rbinformer.Get(ctx).Informer())
+}
+
+// NewMemoryBrokerController initializes the controller for Knative Brokers of
+// the MemoryBroker class.
+func NewMemoryBrokerController(
+	ctx context.Context,
+	cmw cmw.Watcher,
+) *controller.Impl {
+	return newController(ctx, MemoryBrokerClass, mbinformer.Get(ctx).Informer())
+}
+
+// newController initializes a controller for the Knative Broker class, which
+// watches the TriggerMesh brokers informed.
+func newController(ctx context.Context, class string, backingInformer cache.SharedIndexInformer) *controller.Impl {
+	// Conditions at Knative Brokers are computed from the TriggerMesh
+	// broker instead of the channel based broker components.
+	eventingv1.RegisterAlternateBrokerConditionSet(brokerCondSet)
+
+	kbInformer := kbinformer.Get(ctx)
+
+	r := &reconciler{
+		class:    class,
+		client:   eventingclient.Get(ctx),
+		rbLister: rbinformer.Get(ctx).Lister(),
+		mbLister: mbinformer.Get(ctx).Lister(),
+	}
+
+	impl := kbreconciler.NewImpl(ctx, r, class)
+
+	kbInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: pkgreconciler.AnnotationFilterFunc(kbreconciler.ClassAnnotationKey, class, false),
+		Handler:    controller.HandleAll(impl.Enqueue),
+	})
+
+	backingInformer.AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterController(&eventingv1.Broker{}),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	return impl
+}
@@ -0,0 +1,257 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package knativebroker
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	"github.com/triggermesh/triggermesh-core/pkg/client/generated/clientset/internalclientset"
+	eventinglisters "github.com/triggermesh/triggermesh-core/pkg/client/generated/listers/eventing/v1alpha1"
+	"github.com/triggermesh/triggermesh-core/pkg/reconciler/common"
+)
+
+const (
+	// RedisBrokerClass is the Knative Broker class backed by a RedisBroker.
+	RedisBrokerClass = "RedisBroker"
+	// MemoryBrokerClass is the Knative Broker class backed by a MemoryBroker.
+	MemoryBrokerClass = "MemoryBroker"
+)
+
+// BrokerConditionTriggerMeshBroker reflects the readiness of the TriggerMesh
+// broker that backs the Knative Broker.
+const BrokerConditionTriggerMeshBroker apis.ConditionType = "TriggerMeshBrokerReady"
+
+// brokerCondSet replaces the Knative channel based Broker conditions, which do
+// not apply to brokers backed by TriggerMesh.
+var brokerCondSet = apis.NewLivingConditionSet(
+	BrokerConditionTriggerMeshBroker,
+	eventingv1.BrokerConditionAddressable,
+	eventingv1.BrokerConditionDeadLetterSinkResolved,
+)
+
+// BrokerKindForClass returns the TriggerMesh broker kind that backs Knative
+// Brokers of the class, and whether the class is managed by TriggerMesh.
+func BrokerKindForClass(class string) (string, bool) {
+	switch class {
+	case RedisBrokerClass, MemoryBrokerClass:
+		// Class values match the TriggerMesh broker kinds.
+		return class, true
+	}
+	return "", false
+}
+
+type reconciler struct {
+	class string
+
+	client   internalclientset.Interface
+	rbLister eventinglisters.RedisBrokerLister
+	mbLister eventinglisters.MemoryBrokerLister
+}
+
+func (r *reconciler) ReconcileKind(ctx context.Context, b *eventingv1.Broker) pkgreconciler.Event {
+	logging.FromContext(ctx).Infow("Reconciling", zap.Any("Broker", *b))
+
+	var tb eventingv1alpha1.ReconcilableBroker
+	var address *apis.URL
+	var err error
+
+	switch r.class {
+	case RedisBrokerClass:
+		var rb *eventingv1alpha1.RedisBroker
+		if rb, err = r.reconcileRedisBroker(ctx, b); err == nil {
+			tb, address = rb, rb.Status.Address.URL
+		}
+
+	case MemoryBrokerClass:
+		var mb *eventingv1alpha1.MemoryBroker
+		if mb, err = r.reconcileMemoryBroker(ctx, b); err == nil {
+			tb, address = mb, mb.Status.Address.URL
+		}
+
+	default:
+		// Unexpected path.
+		return controller.NewPermanentError(fmt.Errorf("not supported Broker class %q", r.class))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	propagateBrokerStatus(b, tb, address)
+
+	return nil
+}
+
+func (r *reconciler) reconcileRedisBroker(ctx context.Context, b *eventingv1.Broker) (*eventingv1alpha1.RedisBroker, pkgreconciler.Event) {
+	current, err := r.rbLister.RedisBrokers(b.Namespace).Get(b.Name)
+	switch {
+	case apierrs.IsNotFound(err):
+		desired := &eventingv1alpha1.RedisBroker{
+			ObjectMeta: newBackingBrokerMeta(b),
+			Spec: eventingv1alpha1.RedisBrokerSpec{
+				Broker: eventingv1alpha1.CommonBrokerSpec{
+					Delivery: b.Spec.Delivery,
+				},
+			},
+		}
+
+		rb, err := r.client.EventingV1alpha1().RedisBrokers(b.Namespace).Create(ctx, desired, metav1.CreateOptions{})
+		if err != nil {
+			markBackingBrokerFailed(b, common.ReasonFailedBrokerCreate, "Failed to create RedisBroker: %s", err)
+			return nil, pkgreconciler.NewEvent(corev1.EventTypeWarning, common.ReasonFailedBrokerCreate,
+				"Failed to create RedisBroker %s/%s: %w", b.Namespace, b.Name, err)
+		}
+		return rb, nil
+
+	case err != nil:
+		markBackingBrokerFailed(b, common.ReasonFailedBrokerGet, "Failed to get RedisBroker: %s", err)
+		return nil, pkgreconciler.NewEvent(corev1.EventTypeWarning, common.ReasonFailedBrokerGet,
+			"Failed to get RedisBroker %s/%s: %w", b.Namespace, b.Name, err)
+	}
+
+	if err := checkBackingBrokerOwner(b, current); err != nil {
+		return nil, err
+	}
+
+	// Only the delivery spec is managed from the Knative Broker, any other
+	// customization on the backing broker is kept.
+	desired := current.DeepCopy()
+	desired.Spec.Broker.Delivery = b.Spec.Delivery
+	desired.SetDefaults(ctx)
+	if equality.Semantic.DeepEqual(desired.Spec, current.Spec) {
+		return current, nil
+	}
+
+	rb, err := r.client.EventingV1alpha1().RedisBrokers(b.Namespace).Update(ctx, desired, metav1.UpdateOptions{})
+	if err != nil {
+		markBackingBrokerFailed(b, common.ReasonFailedBrokerUpdate, "Failed to update RedisBroker: %s", err)
+		return nil, pkgreconciler.NewEvent(corev1.EventTypeWarning, common.ReasonFailedBrokerUpdate,
+			"Failed to update RedisBroker %s/%s: %w", b.Namespace, b.Name, err)
+	}
+
+	return rb, nil
+}
+
+func (r *reconciler) reconcileMemoryBroker(ctx context.Context, b *eventingv1.Broker) (*eventingv1alpha1.MemoryBroker, pkgreconciler.Event) {
+	current, err := r.mbLister.MemoryBrokers(b.Namespace).Get(b.Name)
+	switch {
+	case apierrs.IsNotFound(err):
+		desired := &eventingv1alpha1.MemoryBroker{
+			ObjectMeta: newBackingBrokerMeta(b),
+			Spec: eventingv1alpha1.MemoryBrokerSpec{
+				Broker: eventingv1alpha1.CommonBrokerSpec{
+					Delivery: b.Spec.Delivery,
+				},
+			},
+		}
+
+		mb, err := r.client.EventingV1alpha1().MemoryBrokers(b.Namespace).Create(ctx, desired, metav1.CreateOptions{})
+		if err != nil {
+			markBackingBrokerFailed(b, common.ReasonFailedBrokerCreate, "Failed to create MemoryBroker: %s", err)
+			return nil, pkgreconciler.NewEvent(corev1.EventTypeWarning, common.ReasonFailedBrokerCreate,
+				"Failed to create MemoryBroker %s/%s: %w", b.Namespace, b.Name, err)
+		}
+		return mb, nil
+
+	case err != nil:
+		markBackingBrokerFailed(b, common.ReasonFailedBrokerGet, "Failed to get MemoryBroker: %s", err)
+		return nil, pkgreconciler.NewEvent(corev1.EventTypeWarning, common.ReasonFailedBrokerGet,
+			"Failed to get MemoryBroker %s/%s: %w", b.Namespace, b.Name, err)
+	}
+
+	if err := checkBackingBrokerOwner(b, current); err != nil {
+		return nil, err
+	}
+
+	// Only the delivery spec is managed from the Knative Broker, any other
+	// customization on the backing broker is kept.
+	desired := current.DeepCopy()
+	desired.Spec.Broker.Delivery = b.Spec.Delivery
+	desired.SetDefaults(ctx)
+	if equality.Semantic.DeepEqual(desired.Spec, current.Spec) {
+		return current, nil
+	}
+
+	mb, err := r.client.EventingV1alpha1().MemoryBrokers(b.Namespace).Update(ctx, desired, metav1.UpdateOptions{})
+	if err != nil {
+		markBackingBrokerFailed(b, common.ReasonFailedBrokerUpdate, "Failed to update MemoryBroker: %s", err)
+		return nil, pkgreconciler.NewEvent(corev1.EventTypeWarning, common.ReasonFailedBrokerUpdate,
+			"Failed to update MemoryBroker %s/%s: %w", b.Namespace, b.Name, err)
+	}
+
+	return mb, nil
+}
+
+// newBackingBrokerMeta returns the metadata for the TriggerMesh broker,
+// which uses the Knative Broker's name and is controlled by it.
+func newBackingBrokerMeta(b *eventingv1.Broker) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace:       b.Namespace,
+		Name:            b.Name,
+		OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(b)},
+	}
+}
+
+// checkBackingBrokerOwner makes sure that an existing TriggerMesh broker is
+// controlled by the Knative Broker before taking it over.
+func checkBackingBrokerOwner(b *eventingv1.Broker, tb eventingv1alpha1.ReconcilableBroker) pkgreconciler.Event {
+	if metav1.IsControlledBy(tb.GetObjectMeta(), b) {
+		return nil
+	}
+
+	kind := tb.GetGroupVersionKind().Kind
+	markBackingBrokerFailed(b, common.ReasonBrokerNotOwned, "%s %q is not owned by the Broker", kind, b.Name)
+	// No need to requeue, we will be notified when the broker changes.
+	return controller.NewPermanentError(fmt.Errorf("%s %s/%s is not owned by the Broker", kind, b.Namespace, b.Name))
+}
+
+func markBackingBrokerFailed(b *eventingv1.Broker, reason, messageFormat string, messageA ...interface{}) {
+	b.GetConditionSet().Manage(&b.Status).MarkFalse(BrokerConditionTriggerMeshBroker, reason, messageFormat, messageA...)
+}
+
+// propagateBrokerStatus reflects the TriggerMesh broker status at the Knative
+// Broker.
+func propagateBrokerStatus(b *eventingv1.Broker, tb eventingv1alpha1.ReconcilableBroker, address *apis.URL) {
+	cs := b.GetConditionSet().Manage(&b.Status)
+	kind := tb.GetGroupVersionKind().Kind
+
+	tbs := tb.GetReconcilableBrokerStatus()
+	switch c := tbs.GetTopLevelCondition(); {
+	case c == nil:
+		cs.MarkUnknown(BrokerConditionTriggerMeshBroker, "BrokerNotReconciled", "%s has not yet been reconciled.", kind)
+	case c.IsTrue():
+		cs.MarkTrue(BrokerConditionTriggerMeshBroker)
+	case c.IsFalse():
+		cs.MarkFalse(BrokerConditionTriggerMeshBroker, c.Reason, c.Message)
+	default:
+		cs.MarkUnknown(BrokerConditionTriggerMeshBroker, c.Reason, c.Message)
+	}
+
+	b.Status.SetAddress(address)
+
+	switch {
+	case b.Spec.Delivery == nil || b.Spec.Delivery.DeadLetterSink == nil:
+		b.Status.MarkDeadLetterSinkNotConfigured()
+	case tbs.GetDeadLetterSinkURI() != nil:
+		b.Status.MarkDeadLetterSinkResolvedSucceeded(tbs.GetDeadLetterSinkURI())
+	default:
+		b.Status.MarkDeadLetterSinkResolvedFailed("DeadLetterSinkNotResolved",
+			"The dead letter sink has not been resolved by %s %q", kind, b.Name)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package knativebroker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+)
+
+func TestPropagateBrokerStatus(t *testing.T) {
+	eventingv1.RegisterAlternateBrokerConditionSet(brokerCondSet)
+
+	address := apis.HTTP("test-rb-broker.test-ns.svc.cluster.local")
+	dls := apis.HTTP("dls.test-ns.svc.cluster.local")
+
+	testCases := map[string]struct {
+		ready    bool
+		address  *apis.URL
+		delivery *eventingduckv1.DeliverySpec
+		dlsURI   *apis.URL
+
+		expectedReady   corev1.ConditionStatus
+		expectedDLS     corev1.ConditionStatus
+		expectedAddress *apis.URL
+	}{
+		"ready": {
+			ready:           true,
+			address:         address,
+			expectedReady:   corev1.ConditionTrue,
+			expectedDLS:     corev1.ConditionTrue,
+			expectedAddress: address,
+		},
+		"not ready": {
+			expectedReady: corev1.ConditionFalse,
+			expectedDLS:   corev1.ConditionTrue,
+		},
+		"dead letter sink resolved": {
+			ready:   true,
+			address: address,
+			delivery: &eventingduckv1.DeliverySpec{
+				DeadLetterSink: &duckv1.Destination{URI: dls},
+			},
+			dlsURI:          dls,
+			expectedReady:   corev1.ConditionTrue,
+			expectedDLS:     corev1.ConditionTrue,
+			expectedAddress: address,
+		},
+		"dead letter sink not resolved": {
+			ready:   true,
+			address: address,
+			delivery: &eventingduckv1.DeliverySpec{
+				DeadLetterSink: &duckv1.Destination{URI: dls},
+			},
+			expectedReady:   corev1.ConditionFalse,
+			expectedDLS:     corev1.ConditionFalse,
+			expectedAddress: address,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			readyStatus := corev1.ConditionFalse
+			if tc.ready {
+				readyStatus = corev1.ConditionTrue
+			}
+
+			rb := &eventingv1alpha1.RedisBroker{}
+			rb.Status.Conditions = duckv1.Conditions{{Type: apis.ConditionReady, Status: readyStatus}}
+			rb.Status.DeadLetterSinkURI = tc.dlsURI
+
+			b := &eventingv1.Broker{Spec: eventingv1.BrokerSpec{Delivery: tc.delivery}}
+			b.Status.InitializeConditions()
+
+			propagateBrokerStatus(b, rb, tc.address)
+
+			assert.Equal(t, tc.expectedReady, b.Status.GetTopLevelCondition().Status)
+			assert.Equal(t, tc.expectedDLS, b.Status.GetCondition(eventingv1.BrokerConditionDeadLetterSinkResolved).Status)
+			assert.Equal(t, tc.expectedAddress, b.Status.Address.URL)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package knativetrigger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+
+	apiseventing "knative.dev/eventing/pkg/apis/eventing"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	kblisters "knative.dev/eventing/pkg/client/listers/eventing/v1"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+
+	eventingclient "github.com/triggermesh/triggermesh-core/pkg/client/generated/injection/client"
+	trginformer "github.com/triggermesh/triggermesh-core/pkg/client/generated/injection/informers/eventing/v1alpha1/trigger"
+	kbinformer "github.com/triggermesh/triggermesh-core/pkg/client/generated/knative/injection/informers/eventing/v1/broker"
+	ktinformer "github.com/triggermesh/triggermesh-core/pkg/client/generated/knative/injection/informers/eventing/v1/trigger"
+	ktreconciler "github.com/triggermesh/triggermesh-core/pkg/client/generated/knative/injection/reconciler/eventing/v1/trigger"
+	"github.com/triggermesh/triggermesh-core/pkg/reconciler/knativebroker"
+)
+
+// NewController initializes the controller for Knative Triggers that
+// reference Knative Brokers backed by TriggerMesh.
+func NewController(
+	ctx context.Context,
+	cmw configmap.Watcher,
+) *controller.Impl {
+	kbInformer := kbinformer.Get(ctx)
+	ktInformer := ktinformer.Get(ctx)
+	trgInformer := trginformer.Get(ctx)
+
+	r := &reconciler{
+		client:    eventingclient.Get(ctx),
+		kbLister:  kbInformer.Lister(),
+		trgLister: trgInformer.Lister(),
+	}
+
+	impl := ktreconciler.NewImpl(ctx, r, func(impl *controller.Impl) controller.Options {
+		return controller.Options{
+			PromoteFilterFunc: filterTriggers(kbInformer.Lister()),
+		}
+	})
+
+	ktInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: filterTriggers(kbInformer.Lister()),
+		Handler:    controller.HandleAll(impl.Enqueue),
+	})
+
+	// Knative Brokers status is propagated to their Triggers.
+	kbInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: filterTriggerMeshClassBroker,
+		Handler: controller.HandleAll(func(obj interface{}) {
+			b, ok := obj.(*eventingv1.Broker)
+			if !ok {
+				return
+			}
+
+			tl, err := ktInformer.Lister().Triggers(b.Namespace).List(labels.Everything())
+			if err != nil {
+				logging.FromContext(ctx).Error("Unable to list Knative Triggers", zap.String("namespace", b.Namespace), zap.Error(err))
+				return
+			}
+
+			for _, t := range tl {
+				if t.Spec.Broker == b.Name {
+					impl.Enqueue(t)
+				}
+			}
+		}),
+	})
+
+	trgInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterController(&eventingv1.Trigger{}),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	return impl
+}
+
+// filterTriggerMeshClassBroker returns true for Knative Brokers whose class is
+// backed by TriggerMesh.
+func filterTriggerMeshClassBroker(obj interface{}) bool {
+	b, ok := obj.(*eventingv1.Broker)
+	if !ok {
+		return false
+	}
+
+	_, ok = knativebroker.BrokerKindForClass(b.GetAnnotations()[apiseventing.BrokerClassKey])
+	return ok
+}
+
+// filterTriggers returns a function that returns true for Knative Triggers
+// that reference a Knative Broker backed by TriggerMesh.
+func filterTriggers(lister kblisters.BrokerLister) func(interface{}) bool {
+	return func(obj interface{}) bool {
+		t, ok := obj.(*eventingv1.Trigger)
+		if !ok {
+			return false
+		}
+
+		b, err := lister.Brokers(t.Namespace).Get(t.Spec.Broker)
+		if err != nil {
+			return false
+		}
+
+		return filterTriggerMeshClassBroker(b)
+	}
+}
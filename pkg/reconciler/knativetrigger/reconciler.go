@@ -0,0 +1,327 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package knativetrigger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apiseventing "knative.dev/eventing/pkg/apis/eventing"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	kblisters "knative.dev/eventing/pkg/client/listers/eventing/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+
+	"github.com/triggermesh/brokers/pkg/config/broker"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	"github.com/triggermesh/triggermesh-core/pkg/client/generated/clientset/internalclientset"
+	eventinglisters "github.com/triggermesh/triggermesh-core/pkg/client/generated/listers/eventing/v1alpha1"
+	"github.com/triggermesh/triggermesh-core/pkg/reconciler/common"
+	"github.com/triggermesh/triggermesh-core/pkg/reconciler/knativebroker"
+)
+
+type reconciler struct {
+	client    internalclientset.Interface
+	kbLister  kblisters.BrokerLister
+	trgLister eventinglisters.TriggerLister
+}
+
+func (r *reconciler) ReconcileKind(ctx context.Context, t *eventingv1.Trigger) pkgreconciler.Event {
+	logging.FromContext(ctx).Infow("Reconciling", zap.Any("Trigger", *t))
+
+	b, err := r.kbLister.Brokers(t.Namespace).Get(t.Spec.Broker)
+	switch {
+	case apierrs.IsNotFound(err):
+		t.Status.MarkBrokerFailed(common.ReasonBrokerDoesNotExist, "Broker %q does not exist", t.Spec.Broker)
+		// No need to requeue, we will be notified when broker is created.
+		return controller.NewPermanentError(err)
+	case err != nil:
+		t.Status.MarkBrokerFailed(common.ReasonFailedBrokerGet, "Failed to get broker %q : %s", t.Spec.Broker, err)
+		return pkgreconciler.NewEvent(corev1.EventTypeWarning, common.ReasonFailedBrokerGet,
+			"Failed to get broker for trigger %s/%s: %w", t.Namespace, t.Name, err)
+	}
+
+	kind, ok := knativebroker.BrokerKindForClass(b.GetAnnotations()[apiseventing.BrokerClassKey])
+	if !ok {
+		// The broker class changed, the Trigger is no longer managed here
+		// and its TriggerMesh Trigger must stop receiving events.
+		return r.deleteTrigger(ctx, t)
+	}
+
+	t.Status.PropagateBrokerCondition(b.Status.GetTopLevelCondition())
+	// Dependency annotations are not supported.
+	t.Status.MarkDependencySucceeded()
+
+	desired, err := newTriggerForKnativeTrigger(t, kind)
+	if err != nil {
+		t.Status.MarkNotSubscribed(common.ReasonTriggerFilterNotSupported, "%s", err)
+		// No need to requeue, we will be notified when the trigger is updated.
+		return controller.NewPermanentError(err)
+	}
+	desired.SetDefaults(ctx)
+
+	trg, err := r.reconcileTrigger(ctx, t, desired)
+	if err != nil {
+		return err
+	}
+
+	propagateTriggerStatus(t, trg)
+
+	return nil
+}
+
+func (r *reconciler) reconcileTrigger(ctx context.Context, t *eventingv1.Trigger, desired *eventingv1alpha1.Trigger) (*eventingv1alpha1.Trigger, pkgreconciler.Event) {
+	current, err := r.trgLister.Triggers(desired.Namespace).Get(desired.Name)
+	switch {
+	case apierrs.IsNotFound(err):
+		trg, err := r.client.EventingV1alpha1().Triggers(desired.Namespace).Create(ctx, desired, metav1.CreateOptions{})
+		if err != nil {
+			t.Status.MarkNotSubscribed(common.ReasonFailedTriggerCreate, "Failed to create TriggerMesh Trigger: %s", err)
+			return nil, pkgreconciler.NewEvent(corev1.EventTypeWarning, common.ReasonFailedTriggerCreate,
+				"Failed to create TriggerMesh Trigger %s/%s: %w", desired.Namespace, desired.Name, err)
+		}
+		return trg, nil
+
+	case err != nil:
+		t.Status.MarkNotSubscribed(common.ReasonFailedTriggerGet, "Failed to get TriggerMesh Trigger: %s", err)
+		return nil, pkgreconciler.NewEvent(corev1.EventTypeWarning, common.ReasonFailedTriggerGet,
+			"Failed to get TriggerMesh Trigger %s/%s: %w", desired.Namespace, desired.Name, err)
+	}
+
+	if !metav1.IsControlledBy(current, t) {
+		t.Status.MarkNotSubscribed(common.ReasonTriggerNotOwned, "TriggerMesh Trigger %q is not owned by the Trigger", current.Name)
+		// No need to requeue, we will be notified when the TriggerMesh Trigger changes.
+		return nil, controller.NewPermanentError(fmt.Errorf("TriggerMesh Trigger %s/%s is not owned by the Trigger", current.Namespace, current.Name))
+	}
+
+	if equality.Semantic.DeepEqual(desired.Spec, current.Spec) {
+		return current, nil
+	}
+
+	updated := current.DeepCopy()
+	updated.Spec = desired.Spec
+
+	trg, err := r.client.EventingV1alpha1().Triggers(updated.Namespace).Update(ctx, updated, metav1.UpdateOptions{})
+	if err != nil {
+		t.Status.MarkNotSubscribed(common.ReasonFailedTriggerUpdate, "Failed to update TriggerMesh Trigger: %s", err)
+		return nil, pkgreconciler.NewEvent(corev1.EventTypeWarning, common.ReasonFailedTriggerUpdate,
+			"Failed to update TriggerMesh Trigger %s/%s: %w", updated.Namespace, updated.Name, err)
+	}
+
+	return trg, nil
+}
+
+// deleteTrigger removes the TriggerMesh Trigger that mirrors the Knative
+// Trigger, if any.
+func (r *reconciler) deleteTrigger(ctx context.Context, t *eventingv1.Trigger) pkgreconciler.Event {
+	current, err := r.trgLister.Triggers(t.Namespace).Get(t.Name)
+	switch {
+	case apierrs.IsNotFound(err):
+		return nil
+	case err != nil:
+		return pkgreconciler.NewEvent(corev1.EventTypeWarning, common.ReasonFailedTriggerGet,
+			"Failed to get TriggerMesh Trigger %s/%s: %w", t.Namespace, t.Name, err)
+	}
+
+	if !metav1.IsControlledBy(current, t) {
+		return nil
+	}
+
+	err = r.client.EventingV1alpha1().Triggers(current.Namespace).Delete(ctx, current.Name, metav1.DeleteOptions{})
+	if err != nil && !apierrs.IsNotFound(err) {
+		return pkgreconciler.NewEvent(corev1.EventTypeWarning, common.ReasonFailedTriggerDelete,
+			"Failed to delete TriggerMesh Trigger %s/%s: %w", current.Namespace, current.Name, err)
+	}
+
+	return nil
+}
+
+// newTriggerForKnativeTrigger returns the TriggerMesh Trigger that mirrors the
+// Knative Trigger, subscribed to the broker kind that backs the Knative Broker.
+func newTriggerForKnativeTrigger(t *eventingv1.Trigger, kind string) (*eventingv1alpha1.Trigger, error) {
+	filters, err := convertTriggerFilters(t)
+	if err != nil {
+		return nil, err
+	}
+
+	return &eventingv1alpha1.Trigger{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       t.Namespace,
+			Name:            t.Name,
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(t)},
+		},
+		Spec: eventingv1alpha1.TriggerSpecBounded{
+			TriggerSpec: eventingv1alpha1.TriggerSpec{
+				Broker: duckv1.KReference{
+					Group: eventingv1alpha1.SchemeGroupVersion.Group,
+					Kind:  kind,
+					Name:  t.Spec.Broker,
+				},
+				Filters:  filters,
+				Target:   *t.Spec.Subscriber.DeepCopy(),
+				Delivery: t.Spec.Delivery.DeepCopy(),
+			},
+		},
+	}, nil
+}
+
+// convertTriggerFilters returns the TriggerMesh filters for the Knative
+// Trigger. As with Knative, the Filters field takes precedence over the
+// attributes based Filter.
+func convertTriggerFilters(t *eventingv1.Trigger) ([]broker.Filter, error) {
+	if len(t.Spec.Filters) != 0 {
+		filters := make([]broker.Filter, 0, len(t.Spec.Filters))
+		for i := range t.Spec.Filters {
+			f, err := convertFilter(&t.Spec.Filters[i])
+			if err != nil {
+				return nil, err
+			}
+			filters = append(filters, *f)
+		}
+		return filters, nil
+	}
+
+	if t.Spec.Filter == nil {
+		return nil, nil
+	}
+
+	// Empty attribute values match any value, which is the same as not
+	// filtering by the attribute.
+	attributes := make(map[string]string, len(t.Spec.Filter.Attributes))
+	for k, v := range t.Spec.Filter.Attributes {
+		if v != eventingv1.TriggerAnyFilter {
+			attributes[k] = v
+		}
+	}
+
+	return splitAttributes(attributes, func(m map[string]string) broker.Filter {
+		return broker.Filter{Exact: m}
+	}), nil
+}
+
+// convertFilter converts a Knative Subscriptions API filter expression into
+// the TriggerMesh equivalent.
+func convertFilter(f *eventingv1.SubscriptionsAPIFilter) (*broker.Filter, error) {
+	if f.CESQL != "" {
+		return nil, errors.New("CESQL filters are not supported by TriggerMesh brokers")
+	}
+
+	var expressions []broker.Filter
+
+	if len(f.All) != 0 {
+		all := make([]broker.Filter, 0, len(f.All))
+		for i := range f.All {
+			nf, err := convertFilter(&f.All[i])
+			if err != nil {
+				return nil, err
+			}
+			all = append(all, *nf)
+		}
+		expressions = append(expressions, broker.Filter{All: all})
+	}
+
+	if len(f.Any) != 0 {
+		anyOf := make([]broker.Filter, 0, len(f.Any))
+		for i := range f.Any {
+			nf, err := convertFilter(&f.Any[i])
+			if err != nil {
+				return nil, err
+			}
+			anyOf = append(anyOf, *nf)
+		}
+		expressions = append(expressions, broker.Filter{Any: anyOf})
+	}
+
+	if f.Not != nil {
+		nf, err := convertFilter(f.Not)
+		if err != nil {
+			return nil, err
+		}
+		expressions = append(expressions, broker.Filter{Not: nf})
+	}
+
+	expressions = append(expressions, splitAttributes(f.Exact, func(m map[string]string) broker.Filter {
+		return broker.Filter{Exact: m}
+	})...)
+	expressions = append(expressions, splitAttributes(f.Prefix, func(m map[string]string) broker.Filter {
+		return broker.Filter{Prefix: m}
+	})...)
+	expressions = append(expressions, splitAttributes(f.Suffix, func(m map[string]string) broker.Filter {
+		return broker.Filter{Suffix: m}
+	})...)
+
+	switch len(expressions) {
+	case 0:
+		return nil, errors.New("empty filter expressions are not supported by TriggerMesh brokers")
+	case 1:
+		return &expressions[0], nil
+	}
+
+	return &broker.Filter{All: expressions}, nil
+}
+
+// splitAttributes returns a filter per attribute, since TriggerMesh filter
+// dialects accept a single attribute each. Filters are sorted by attribute
+// name to keep the generated spec stable.
+func splitAttributes(attributes map[string]string, newFilter func(map[string]string) broker.Filter) []broker.Filter {
+	if len(attributes) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(attributes))
+	for k := range attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	filters := make([]broker.Filter, 0, len(keys))
+	for _, k := range keys {
+		filters = append(filters, newFilter(map[string]string{k: attributes[k]}))
+	}
+
+	return filters
+}
+
+// propagateTriggerStatus reflects the TriggerMesh Trigger status at the
+// Knative Trigger.
+func propagateTriggerStatus(t *eventingv1.Trigger, trg *eventingv1alpha1.Trigger) {
+	t.Status.PropagateSubscriptionCondition(trg.Status.GetTopLevelCondition())
+
+	t.Status.SubscriberURI = trg.Status.TargetURI
+	switch c := trg.Status.GetCondition(eventingv1alpha1.TriggerConditionTargetResolved); {
+	case c == nil:
+		t.Status.MarkSubscriberResolvedUnknown("TargetNotResolved", "The TriggerMesh Trigger target has not been resolved yet.")
+	case c.IsTrue():
+		t.Status.MarkSubscriberResolvedSucceeded()
+	case c.IsFalse():
+		t.Status.MarkSubscriberResolvedFailed(c.Reason, c.Message)
+	default:
+		t.Status.MarkSubscriberResolvedUnknown(c.Reason, c.Message)
+	}
+
+	t.Status.DeadLetterSinkURI = trg.Status.DeadLetterSinkURI
+	switch c := trg.Status.GetCondition(eventingv1alpha1.TriggerConditionDeadLetterSinkResolved); {
+	case t.Spec.Delivery == nil || t.Spec.Delivery.DeadLetterSink == nil:
+		t.Status.MarkDeadLetterSinkNotConfigured()
+	case c != nil && c.IsTrue():
+		t.Status.MarkDeadLetterSinkResolvedSucceeded()
+	case c != nil && c.IsFalse():
+		t.Status.MarkDeadLetterSinkResolvedFailed(c.Reason, c.Message)
+	default:
+		t.Status.MarkDeadLetterSinkResolvedFailed("DeadLetterSinkNotResolved",
+			"The TriggerMesh Trigger dead letter sink has not been resolved yet.")
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package knativetrigger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+
+	apiseventing "knative.dev/eventing/pkg/apis/eventing"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	kblisters "knative.dev/eventing/pkg/client/listers/eventing/v1"
+	"knative.dev/pkg/kmeta"
+
+	"github.com/triggermesh/brokers/pkg/config/broker"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	fakeclientset "github.com/triggermesh/triggermesh-core/pkg/client/generated/clientset/internalclientset/fake"
+	tmt "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing"
+	tresources "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing/resources"
+	tmtv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing/v1alpha1"
+)
+
+func TestConvertTriggerFilters(t *testing.T) {
+	testCases := map[string]struct {
+		spec eventingv1.TriggerSpec
+
+		expectedFilters []broker.Filter
+		expectedErr     string
+	}{
+		"no filter": {},
+		"attributes": {
+			spec: eventingv1.TriggerSpec{
+				Filter: &eventingv1.TriggerFilter{
+					Attributes: eventingv1.TriggerFilterAttributes{
+						"type":   "example.type",
+						"source": "example.source",
+						"any":    eventingv1.TriggerAnyFilter,
+					},
+				},
+			},
+			expectedFilters: []broker.Filter{
+				{Exact: map[string]string{"source": "example.source"}},
+				{Exact: map[string]string{"type": "example.type"}},
+			},
+		},
+		"filters take precedence": {
+			spec: eventingv1.TriggerSpec{
+				Filter: &eventingv1.TriggerFilter{
+					Attributes: eventingv1.TriggerFilterAttributes{"type": "ignored"},
+				},
+				Filters: []eventingv1.SubscriptionsAPIFilter{
+					{Prefix: map[string]string{"type": "example."}},
+				},
+			},
+			expectedFilters: []broker.Filter{
+				{Prefix: map[string]string{"type": "example."}},
+			},
+		},
+		"nested filters": {
+			spec: eventingv1.TriggerSpec{
+				Filters: []eventingv1.SubscriptionsAPIFilter{{
+					Any: []eventingv1.SubscriptionsAPIFilter{
+						{Exact: map[string]string{"type": "a", "source": "b"}},
+						{Not: &eventingv1.SubscriptionsAPIFilter{Suffix: map[string]string{"subject": ".tmp"}}},
+					},
+				}},
+			},
+			expectedFilters: []broker.Filter{{
+				Any: []broker.Filter{
+					{All: []broker.Filter{
+						{Exact: map[string]string{"source": "b"}},
+						{Exact: map[string]string{"type": "a"}},
+					}},
+					{Not: &broker.Filter{Suffix: map[string]string{"subject": ".tmp"}}},
+				},
+			}},
+		},
+		"cesql": {
+			spec: eventingv1.TriggerSpec{
+				Filters: []eventingv1.SubscriptionsAPIFilter{
+					{All: []eventingv1.SubscriptionsAPIFilter{{CESQL: "type = 'a'"}}},
+				},
+			},
+			expectedErr: "CESQL filters are not supported by TriggerMesh brokers",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			filters, err := convertTriggerFilters(&eventingv1.Trigger{Spec: tc.spec})
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedFilters, filters)
+		})
+	}
+}
+
+func TestReconcileKindBrokerClassChanged(t *testing.T) {
+	kb := &eventingv1.Broker{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   tresources.TestNamespace,
+			Name:        "broker",
+			Annotations: map[string]string{apiseventing.BrokerClassKey: "MTChannelBasedBroker"},
+		},
+	}
+
+	kt := &eventingv1.Trigger{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: tresources.TestNamespace,
+			Name:      tresources.TestName,
+			UID:       "trigger-uid",
+		},
+		Spec: eventingv1.TriggerSpec{Broker: kb.Name},
+	}
+
+	testCases := map[string]struct {
+		trigger *eventingv1alpha1.Trigger
+
+		expectTrigger bool
+	}{
+		"no mirrored trigger": {},
+		"mirrored trigger": {
+			trigger: tmtv1alpha1.NewTrigger(tresources.TestNamespace, tresources.TestName, kb.Name,
+				func(trg *eventingv1alpha1.Trigger) {
+					trg.OwnerReferences = []metav1.OwnerReference{*kmeta.NewControllerRef(kt)}
+				}),
+		},
+		"trigger not owned": {
+			trigger:       tmtv1alpha1.NewTrigger(tresources.TestNamespace, tresources.TestName, kb.Name),
+			expectTrigger: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var objs []runtime.Object
+			if tc.trigger != nil {
+				objs = append(objs, tc.trigger)
+			}
+			ls := tmt.NewListers(objs)
+			client := fakeclientset.NewSimpleClientset(objs...)
+
+			kbIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			require.NoError(t, kbIndexer.Add(kb))
+
+			r := &reconciler{
+				client:    client,
+				kbLister:  kblisters.NewBrokerLister(kbIndexer),
+				trgLister: ls.GetTriggerLister(),
+			}
+
+			require.NoError(t, r.ReconcileKind(ctx, kt.DeepCopy()))
+
+			_, err := client.EventingV1alpha1().Triggers(tresources.TestNamespace).Get(ctx, tresources.TestName, metav1.GetOptions{})
+			if tc.expectTrigger {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, apierrs.IsNotFound(err), "expected TriggerMesh Trigger not to exist, got %v", err)
+			}
+		})
+	}
+}
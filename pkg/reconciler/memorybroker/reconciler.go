@@ -9,12 +9,10 @@ import (
 
 	"go.uber.org/zap"
 	appsv1 "k8s.io/api/apps/v1"
-	corev1 "k8s.io/api/core/v1"
 
-	"knative.dev/pkg/apis"
 	"knative.dev/pkg/logging"
-	"knative.dev/pkg/network"
 	knreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/resolver"
 
 	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
 	"github.com/triggermesh/triggermesh-core/pkg/reconciler/common"
@@ -26,6 +24,12 @@ type reconciler struct {
 	configMapReconciler common.ConfigMapReconciler
 	saReconciler        common.ServiceAccountReconciler
 	brokerReconciler    common.BrokerReconciler
+
+	// referencesReconciler is set after the controller implementation
+	// is created, since it depends on its tracker.
+	referencesReconciler common.ReferencesReconciler
+
+	uriResolver *resolver.URIResolver
 }
 
 // options that set Broker environment variables specific for the MemoryBroker.
@@ -48,6 +52,14 @@ func memoryDeploymentOption(mb *eventingv1alpha1.MemoryBroker) resources.Deploym
 func (r *reconciler) ReconcileKind(ctx context.Context, mb *eventingv1alpha1.MemoryBroker) knreconciler.Event {
 	logging.FromContext(ctx).Infow("Reconciling", zap.Any("MemoryBroker", *mb))
 
+	// Brokers created before the webhook was deployed might not be defaulted.
+	mb.SetDefaults(ctx)
+
+	// Resolve the broker level DLS before rendering the configuration.
+	if err := common.ResolveBrokerDeadLetterSink(ctx, r.uriResolver, mb); err != nil {
+		return err
+	}
+
 	// Iterate triggers and create secret.
 	secret, err := r.secretReconciler.Reconcile(ctx, mb)
 	if err != nil {
@@ -66,28 +78,22 @@ func (r *reconciler) ReconcileKind(ctx context.Context, mb *eventingv1alpha1.Mem
 		return err
 	}
 
-	// Make sure the Broker deployment exists.
-	_, brokerSvc, err := r.brokerReconciler.Reconcile(ctx, mb, sa, secret, configMap, memoryDeploymentOption(mb))
+	// Track referenced objects so that changes roll out the Broker pods.
+	refsOption, err := r.referencesReconciler.Reconcile(ctx, mb)
 	if err != nil {
 		return err
 	}
 
-	// Set address to the Broker service.
-	mb.Status.SetAddress(getServiceAddress(brokerSvc))
-
-	return nil
-}
-
-func getServiceAddress(svc *corev1.Service) *apis.URL {
-	if svc == nil || len(svc.Spec.Ports) == 0 {
-		return nil
+	// Make sure the Broker deployment exists.
+	_, brokerSvc, err := r.brokerReconciler.Reconcile(ctx, mb, sa, secret, configMap, memoryDeploymentOption(mb), refsOption)
+	if err != nil {
+		return err
 	}
 
-	var port string
-	if svc.Spec.Ports[0].Port != 80 {
-		port = ":" + strconv.Itoa(int(svc.Spec.Ports[0].Port))
-	}
+	// Set addresses to the Broker service.
+	address, addresses := common.BrokerAddresses(brokerSvc)
+	mb.Status.SetAddress(address)
+	mb.Status.Addresses = addresses
 
-	return apis.HTTP(
-		network.GetServiceHostname(svc.Name, svc.Namespace) + port)
+	return nil
 }
@@ -70,8 +70,11 @@ func TestAllCases(t *testing.T) {
 						tmtv1alpha1.MemoryBrokerWithStatusCondition("BrokerServiceAccountReady", corev1.ConditionTrue, "", ""),
 						tmtv1alpha1.MemoryBrokerWithStatusCondition("BrokerServiceReady", corev1.ConditionTrue, "", ""),
 						tmtv1alpha1.MemoryBrokerWithStatusCondition("BrokerStatusConfigReady", corev1.ConditionTrue, "", ""),
+						tmtv1alpha1.MemoryBrokerWithStatusCondition("DeadLetterSinkResolved", corev1.ConditionTrue, "DeadLetterSinkNotConfigured", "No dead letter sink is configured."),
+						tmtv1alpha1.MemoryBrokerWithStatusCondition("ExposureReady", corev1.ConditionTrue, "ExposureNotConfigured", "The broker is not exposed outside the cluster."),
 						tmtv1alpha1.MemoryBrokerWithStatusCondition("MemoryBrokerBrokerRoleBinding", corev1.ConditionTrue, "", ""),
 						tmtv1alpha1.MemoryBrokerWithStatusCondition("Ready", corev1.ConditionFalse, "UnavailableEndpoints", "Endpoints for broker service do not exist"),
+						tmtv1alpha1.MemoryBrokerWithStatusCondition("ReferencesResolved", corev1.ConditionTrue, "", ""),
 					),
 				},
 			},
@@ -90,6 +93,8 @@ func TestAllCases(t *testing.T) {
 					tmtv1alpha1.MemoryBrokerWithStatusCondition("BrokerServiceAccountReady", corev1.ConditionTrue, "", ""),
 					tmtv1alpha1.MemoryBrokerWithStatusCondition("BrokerServiceReady", corev1.ConditionTrue, "", ""),
 					tmtv1alpha1.MemoryBrokerWithStatusCondition("BrokerStatusConfigReady", corev1.ConditionTrue, "", ""),
+					tmtv1alpha1.MemoryBrokerWithStatusCondition("DeadLetterSinkResolved", corev1.ConditionTrue, "DeadLetterSinkNotConfigured", "No dead letter sink is configured."),
+					tmtv1alpha1.MemoryBrokerWithStatusCondition("ExposureReady", corev1.ConditionTrue, "ExposureNotConfigured", "The broker is not exposed outside the cluster."),
 					tmtv1alpha1.MemoryBrokerWithStatusCondition("MemoryBrokerBrokerRoleBinding", corev1.ConditionTrue, "", ""),
 					tmtv1alpha1.MemoryBrokerWithStatusCondition("Ready", corev1.ConditionFalse, "UnavailableEndpoints", "Endpoints for broker service do not exist"),
 				),
@@ -111,9 +116,13 @@ func TestAllCases(t *testing.T) {
 						tmtv1alpha1.MemoryBrokerWithStatusCondition("BrokerServiceAccountReady", corev1.ConditionTrue, "", ""),
 						tmtv1alpha1.MemoryBrokerWithStatusCondition("BrokerServiceReady", corev1.ConditionTrue, "", ""),
 						tmtv1alpha1.MemoryBrokerWithStatusCondition("BrokerStatusConfigReady", corev1.ConditionTrue, "", ""),
+						tmtv1alpha1.MemoryBrokerWithStatusCondition("DeadLetterSinkResolved", corev1.ConditionTrue, "DeadLetterSinkNotConfigured", "No dead letter sink is configured."),
+						tmtv1alpha1.MemoryBrokerWithStatusCondition("ExposureReady", corev1.ConditionTrue, "ExposureNotConfigured", "The broker is not exposed outside the cluster."),
 						tmtv1alpha1.MemoryBrokerWithStatusCondition("MemoryBrokerBrokerRoleBinding", corev1.ConditionTrue, "", ""),
 						tmtv1alpha1.MemoryBrokerWithStatusCondition("Ready", corev1.ConditionTrue, "", ""),
+						tmtv1alpha1.MemoryBrokerWithStatusCondition("ReferencesResolved", corev1.ConditionTrue, "", ""),
 						tmtv1alpha1.MemoryBrokerWithStatusAddress("http://"+tresources.TestName+"-mb-broker."+tresources.TestNamespace+".svc.cluster.local"),
+						tmtv1alpha1.MemoryBrokerWithStatusAddressable("http", "http://"+tresources.TestName+"-mb-broker."+tresources.TestNamespace+".svc.cluster.local"),
 					),
 				},
 			},
@@ -138,6 +147,8 @@ func TestAllCases(t *testing.T) {
 			secretReconciler: common.NewSecretReconciler(ctx,
 				listers.GetSecretLister(),
 				listers.GetTriggerLister(),
+				listers.GetBrokerLister(),
+				listers.GetNamespaceLister(),
 			),
 			configMapReconciler: common.NewConfigMapReconciler(ctx,
 				listers.GetConfigMapLister(),
@@ -147,10 +158,18 @@ func TestAllCases(t *testing.T) {
 				listers.GetRoleBindingLister(),
 			),
 			brokerReconciler: common.NewBrokerReconciler(ctx,
+				&knt.FakeTracker{},
 				listers.GetDeploymentLister(),
+				listers.GetHorizontalPodAutoscalerLister(),
 				listers.GetServiceLister(),
 				listers.GetEndpointsLister(),
+				listers.GetIngressLister(),
 				tresources.TestBrokerImage, corev1.PullAlways),
+			referencesReconciler: common.NewReferencesReconciler(ctx,
+				&knt.FakeTracker{},
+				listers.GetSecretLister(),
+				listers.GetConfigMapLister(),
+			),
 		}
 
 		return memorybroker.NewReconciler(ctx, logger,
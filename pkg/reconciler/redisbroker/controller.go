@@ -5,28 +5,37 @@ package redisbroker
 
 import (
 	"context"
+	"time"
 
 	"github.com/kelseyhightower/envconfig"
 	"go.uber.org/zap"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
 
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/client/injection/kube/informers/apps/v1/deployment"
+	hpainformer "knative.dev/pkg/client/injection/kube/informers/autoscaling/v2/horizontalpodautoscaler"
 	"knative.dev/pkg/client/injection/kube/informers/core/v1/configmap"
 	endpointsinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/endpoints"
+	nsinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/namespace"
+	pvcinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/persistentvolumeclaim"
 	"knative.dev/pkg/client/injection/kube/informers/core/v1/secret"
 	"knative.dev/pkg/client/injection/kube/informers/core/v1/service"
 	"knative.dev/pkg/client/injection/kube/informers/core/v1/serviceaccount"
+	ingressinformer "knative.dev/pkg/client/injection/kube/informers/networking/v1/ingress"
 	rolebindingsinformer "knative.dev/pkg/client/injection/kube/informers/rbac/v1/rolebinding"
 	cmw "knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
+	"knative.dev/pkg/resolver"
 
 	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	eventingclient "github.com/triggermesh/triggermesh-core/pkg/client/generated/injection/client"
+	brinformer "github.com/triggermesh/triggermesh-core/pkg/client/generated/injection/informers/eventing/v1alpha1/broker"
 	rbinformer "github.com/triggermesh/triggermesh-core/pkg/client/generated/injection/informers/eventing/v1alpha1/redisbroker"
 	trginformer "github.com/triggermesh/triggermesh-core/pkg/client/generated/injection/informers/eventing/v1alpha1/trigger"
 
@@ -42,6 +51,10 @@ type envConfig struct {
 	RedisImage            string `envconfig:"REDISBROKER_REDIS_IMAGE" required:"true"`
 	BrokerImage           string `envconfig:"REDISBROKER_BROKER_IMAGE" required:"true"`
 	BrokerImagePullPolicy string `envconfig:"REDISBROKER_BROKER_IMAGE_PULL_POLICY" default:"IfNotPresent"`
+	// Interval for refreshing the Redis stream status, set to 0 to disable.
+	StreamStatusResyncPeriod time.Duration `envconfig:"REDISBROKER_STREAM_STATUS_RESYNC_PERIOD" default:"30s"`
+	// Interval for trimming Redis streams that set retention limits, set to 0 to disable.
+	StreamRetentionPeriod time.Duration `envconfig:"REDISBROKER_STREAM_RETENTION_PERIOD" default:"1m"`
 }
 
 // NewController initializes the controller and is called by the generated code
@@ -58,33 +71,64 @@ func NewController(
 
 	rbInformer := rbinformer.Get(ctx)
 	trgInformer := trginformer.Get(ctx)
+	brInformer := brinformer.Get(ctx)
 	secretInformer := secret.Get(ctx)
 	configMapInformer := configmap.Get(ctx)
+	namespaceInformer := nsinformer.Get(ctx)
 	deploymentInformer := deployment.Get(ctx)
+	hpaInformer := hpainformer.Get(ctx)
 	serviceInformer := service.Get(ctx)
 	endpointsInformer := endpointsinformer.Get(ctx)
+	ingressInformer := ingressinformer.Get(ctx)
+	pvcInformer := pvcinformer.Get(ctx)
 	serviceAccountInformer := serviceaccount.Get(ctx)
 	roleBindingsInformer := rolebindingsinformer.Get(ctx)
 
 	_ = rolebindingsinformer.Get(ctx)
 
+	cleaner := &redisCleaner{
+		secretLister: secretInformer.Lister(),
+	}
+
 	r := &reconciler{
-		secretReconciler:    common.NewSecretReconciler(ctx, secretInformer.Lister(), trgInformer.Lister()),
+		secretReconciler:    common.NewSecretReconciler(ctx, secretInformer.Lister(), trgInformer.Lister(), brInformer.Lister(), namespaceInformer.Lister()),
 		configMapReconciler: common.NewConfigMapReconciler(ctx, configMapInformer.Lister()),
 		saReconciler:        common.NewServiceAccountReconciler(ctx, serviceAccountInformer.Lister(), roleBindingsInformer.Lister()),
-		brokerReconciler: common.NewBrokerReconciler(ctx, deploymentInformer.Lister(), serviceInformer.Lister(), endpointsInformer.Lister(),
-			env.BrokerImage, corev1.PullPolicy(env.BrokerImagePullPolicy)),
 
 		redisReconciler: redisReconciler{
 			client:           kubeclient.Get(ctx),
 			deploymentLister: deploymentInformer.Lister(),
 			serviceLister:    serviceInformer.Lister(),
 			endpointsLister:  endpointsInformer.Lister(),
+			pvcLister:        pvcInformer.Lister(),
 			image:            env.RedisImage,
 		},
+		redisConnectionChecker: redisConnectionChecker{
+			secretLister: secretInformer.Lister(),
+		},
+		redisStreamStatusReconciler: redisStreamStatusReconciler{
+			secretLister: secretInformer.Lister(),
+		},
+		redisStreamTrimmer: redisStreamTrimmer{
+			secretLister: secretInformer.Lister(),
+		},
+		redisCleaner: cleaner,
+		triggerFinalizer: triggerFinalizer{
+			client:        eventingclient.Get(ctx),
+			triggerLister: trgInformer.Lister(),
+			brokerLister:  brInformer.Lister(),
+			cleaner:       cleaner,
+		},
+		streamStatusResyncPeriod: env.StreamStatusResyncPeriod,
+		streamRetentionPeriod:    env.StreamRetentionPeriod,
 	}
 
 	impl := rbreconciler.NewImpl(ctx, r)
+	r.uriResolver = resolver.NewURIResolverFromTracker(ctx, impl.Tracker)
+	r.enqueueAfter = impl.EnqueueAfter
+	r.referencesReconciler = common.NewReferencesReconciler(ctx, impl.Tracker, secretInformer.Lister(), configMapInformer.Lister())
+	r.brokerReconciler = common.NewBrokerReconciler(ctx, impl.Tracker, deploymentInformer.Lister(), hpaInformer.Lister(), serviceInformer.Lister(), endpointsInformer.Lister(), ingressInformer.Lister(),
+		env.BrokerImage, corev1.PullPolicy(env.BrokerImagePullPolicy))
 
 	rb := &eventingv1alpha1.RedisBroker{}
 	gvk := rb.GetGroupVersionKind()
@@ -95,14 +139,29 @@ func NewController(
 		FilterFunc: controller.FilterController(rb),
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 	})
+
+	// Secrets and ConfigMaps referenced by brokers are notified to the tracker.
+	secretInformer.Informer().AddEventHandler(controller.HandleAll(
+		controller.EnsureTypeMeta(impl.Tracker.OnChanged, corev1.SchemeGroupVersion.WithKind("Secret"))))
+	configMapInformer.Informer().AddEventHandler(controller.HandleAll(
+		controller.EnsureTypeMeta(impl.Tracker.OnChanged, corev1.SchemeGroupVersion.WithKind("ConfigMap"))))
+
 	deploymentInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: controller.FilterController(rb),
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 	})
+	hpaInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterController(rb),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
 	serviceInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: controller.FilterController(rb),
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 	})
+	ingressInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterController(rb),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
 	endpointsInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: func(obj interface{}) bool {
 			ep, ok := obj.(*corev1.Endpoints)
@@ -128,6 +187,10 @@ func NewController(
 			impl.EnqueueControllerOf(svc)
 		}),
 	})
+	pvcInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.FilterController(rb),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
 	serviceAccountInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: controller.FilterController(rb),
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
@@ -144,22 +207,24 @@ func NewController(
 			return false
 		}
 
-		// TODO replace with defaulting when webhook is implemented
+		// Triggers created before the webhook was deployed might not be defaulted.
 		if !(t.Spec.Broker.Group == gvk.Group || t.Spec.Broker.Group == "") ||
-			t.Spec.Broker.Kind != gvk.Kind {
+			(t.Spec.Broker.Kind != gvk.Kind && !t.ReferencesClassBroker()) {
 			return false
 		}
 
-		// TODO replace with broker namespace when webhook defaulting is implemented
-		_, err := rbInformer.Lister().RedisBrokers(t.Namespace).Get(t.Spec.Broker.Name)
+		b, err := rbInformer.Lister().RedisBrokers(t.BrokerNamespace()).Get(t.Spec.Broker.Name)
 		switch {
 		case err == nil:
-			return true
+			// Class based Brokers are served by a broker they control.
+			return t.Spec.Broker.Kind == gvk.Kind || common.TriggerMatchesBroker(t, b, brInformer.Lister())
 		case !apierrs.IsNotFound(err):
 			logging.FromContext(ctx).Error("Unable to get Redis Broker", zap.Any("broker", t.Spec.Broker), zap.Error(err))
+			return false
 		}
 
-		return false
+		// Triggers that outlive their broker need the finalizer released.
+		return hasTriggerFinalizer(t)
 	}
 
 	enqueueFromTrigger := func(obj interface{}) {
@@ -170,7 +235,7 @@ func NewController(
 
 		impl.EnqueueKey(types.NamespacedName{
 			Name:      t.Spec.Broker.Name,
-			Namespace: t.Namespace,
+			Namespace: t.BrokerNamespace(),
 		})
 	}
 
@@ -179,5 +244,28 @@ func NewController(
 		Handler:    controller.HandleAll(enqueueFromTrigger),
 	})
 
+	// Namespace labels decide whether their Triggers are allowed to subscribe
+	// to brokers at other namespaces.
+	enqueueFromNamespace := func(obj interface{}) {
+		ns, ok := obj.(*corev1.Namespace)
+		if !ok {
+			return
+		}
+
+		tl, err := trgInformer.Lister().Triggers(ns.Name).List(labels.Everything())
+		if err != nil {
+			logging.FromContext(ctx).Error("Unable to list Triggers", zap.String("namespace", ns.Name), zap.Error(err))
+			return
+		}
+
+		for _, t := range tl {
+			if t.BrokerNamespace() != ns.Name && filterTriggerForRedisBroker(t) {
+				enqueueFromTrigger(t)
+			}
+		}
+	}
+
+	namespaceInformer.Informer().AddEventHandler(controller.HandleAll(enqueueFromNamespace))
+
 	return impl
 }
@@ -9,6 +9,7 @@ import (
 	"go.uber.org/zap"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -16,6 +17,7 @@ import (
 	appsv1listers "k8s.io/client-go/listers/apps/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"knative.dev/eventing/pkg/apis/duck"
+	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
 	pkgreconciler "knative.dev/pkg/reconciler"
 
@@ -27,6 +29,13 @@ import (
 
 const (
 	redisResourceSuffix = "rb-redis"
+
+	// redisDataVolumeName is the volume that hosts the Redis data when
+	// persistence is configured.
+	redisDataVolumeName = "redis-data"
+	// redisDataPath is the Redis working directory, where the append only
+	// file is written.
+	redisDataPath = "/data"
 )
 
 type redisReconciler struct {
@@ -34,6 +43,7 @@ type redisReconciler struct {
 	deploymentLister appsv1listers.DeploymentLister
 	serviceLister    corev1listers.ServiceLister
 	endpointsLister  corev1listers.EndpointsLister
+	pvcLister        corev1listers.PersistentVolumeClaimLister
 	image            string
 }
 
@@ -44,6 +54,10 @@ func (r *redisReconciler) reconcile(ctx context.Context, rb *eventingv1alpha1.Re
 		return nil, nil, nil
 	}
 
+	if err := r.reconcilePersistentVolumeClaim(ctx, rb); err != nil {
+		return nil, nil, err
+	}
+
 	d, err := r.reconcileDeployment(ctx, rb)
 	if err != nil {
 		return nil, nil, err
@@ -63,6 +77,39 @@ func (r *redisReconciler) reconcile(ctx context.Context, rb *eventingv1alpha1.Re
 }
 
 func buildRedisDeployment(rb *eventingv1alpha1.RedisBroker, image string) *appsv1.Deployment {
+	var pt *eventingv1alpha1.PodTemplate
+	var persistence *eventingv1alpha1.RedisPersistence
+	if rb.Spec.Redis != nil {
+		pt = rb.Spec.Redis.PodTemplate
+		persistence = rb.Spec.Redis.Persistence
+	}
+	mopts, psopts, copts := common.PodTemplateOptions(pt)
+
+	// Persisted data can only be mounted by one instance at a time, old
+	// pods need to be removed before creating new ones.
+	strategy := appsv1.RollingUpdateDeploymentStrategyType
+	if persistence != nil {
+		strategy = appsv1.RecreateDeploymentStrategyType
+		copts = append(copts, resources.ContainerAddVolumeMount(
+			resources.NewVolumeMount(redisDataVolumeName, redisDataPath)))
+		psopts = append(psopts, resources.PodSpecAddVolume(
+			resources.NewVolume(redisDataVolumeName,
+				resources.VolumeFromPersistentVolumeClaimOption(redisPersistentVolumeClaimName(rb)))))
+	}
+
+	mopts = append(mopts,
+		resources.MetaAddLabel(resources.AppPartOfLabel, resources.PartOf),
+		resources.MetaAddLabel(resources.AppManagedByLabel, resources.ManagedBy),
+	)
+
+	copts = append(copts,
+		resources.ContainerAddEnvFromValue("REDIS_ARGS", "--appendonly yes"),
+		resources.ContainerAddPort("redis", 6379))
+
+	psopts = append(psopts,
+		resources.PodSpecAddContainer(
+			resources.NewContainer("redis", image, copts...)))
+
 	return resources.NewDeployment(rb.Namespace, rb.Name+"-"+redisResourceSuffix,
 		resources.DeploymentWithMetaOptions(
 			resources.MetaAddLabel(resources.AppNameLabel, common.AppAnnotationValue(rb)),
@@ -71,19 +118,14 @@ func buildRedisDeployment(rb *eventingv1alpha1.RedisBroker, image string) *appsv
 			resources.MetaAddLabel(resources.AppManagedByLabel, resources.ManagedBy),
 			resources.MetaAddLabel(resources.AppInstanceLabel, rb.Name+"-"+redisResourceSuffix),
 			resources.MetaAddOwner(rb, rb.GetGroupVersionKind())),
-		resources.DeploymentAddSelectorForTemplate(resources.AppComponentLabel, "redis-deployment"),
-		resources.DeploymentAddSelectorForTemplate(resources.AppInstanceLabel, rb.Name+"-"+redisResourceSuffix),
 		resources.DeploymentSetReplicas(1),
+		resources.DeploymentWithStrategy(strategy),
 		resources.DeploymentWithTemplateSpecOptions(
-			resources.PodTemplateSpecWithMetaOptions(
-				resources.MetaAddLabel(resources.AppPartOfLabel, resources.PartOf),
-				resources.MetaAddLabel(resources.AppManagedByLabel, resources.ManagedBy),
-			),
-			resources.PodTemplateSpecWithPodSpecOptions(
-				resources.PodSpecAddContainer(
-					resources.NewContainer("redis", image,
-						resources.ContainerAddEnvFromValue("REDIS_ARGS", "--appendonly yes"),
-						resources.ContainerAddPort("redis", 6379))))))
+			resources.PodTemplateSpecWithMetaOptions(mopts...),
+			resources.PodTemplateSpecWithPodSpecOptions(psopts...)),
+		// Selector labels are set after the template ones to take precedence.
+		resources.DeploymentAddSelectorForTemplate(resources.AppComponentLabel, "redis-deployment"),
+		resources.DeploymentAddSelectorForTemplate(resources.AppInstanceLabel, rb.Name+"-"+redisResourceSuffix))
 }
 
 func (r *redisReconciler) reconcileDeployment(ctx context.Context, rb *eventingv1alpha1.RedisBroker) (*appsv1.Deployment, error) {
@@ -135,6 +177,97 @@ func (r *redisReconciler) reconcileDeployment(ctx context.Context, rb *eventingv
 	return current, nil
 }
 
+func redisPersistentVolumeClaimName(rb *eventingv1alpha1.RedisBroker) string {
+	return rb.Name + "-" + redisResourceSuffix + "-data"
+}
+
+func buildRedisPersistentVolumeClaim(rb *eventingv1alpha1.RedisBroker) *corev1.PersistentVolumeClaim {
+	p := rb.Spec.Redis.Persistence
+	name := redisPersistentVolumeClaimName(rb)
+
+	return resources.NewPersistentVolumeClaim(rb.Namespace, name,
+		resources.PersistentVolumeClaimWithMetaOptions(
+			resources.MetaAddLabel(resources.AppNameLabel, common.AppAnnotationValue(rb)),
+			resources.MetaAddLabel(resources.AppComponentLabel, "redis-data"),
+			resources.MetaAddLabel(resources.AppPartOfLabel, resources.PartOf),
+			resources.MetaAddLabel(resources.AppManagedByLabel, resources.ManagedBy),
+			resources.MetaAddLabel(resources.AppInstanceLabel, name),
+			resources.MetaAddOwner(rb, rb.GetGroupVersionKind())),
+		resources.PersistentVolumeClaimWithStorageClassName(p.StorageClassName),
+		resources.PersistentVolumeClaimAddAccessMode(p.AccessMode),
+		resources.PersistentVolumeClaimWithStorageRequest(p.Size))
+}
+
+// reconcilePersistentVolumeClaim makes sure that the claim for the Redis data
+// exists when persistence is configured. Claims are not removed when
+// persistence is disabled to avoid data loss, they will be garbage collected
+// along with the broker.
+func (r *redisReconciler) reconcilePersistentVolumeClaim(ctx context.Context, rb *eventingv1alpha1.RedisBroker) error {
+	if rb.Spec.Redis == nil || rb.Spec.Redis.Persistence == nil {
+		rb.Status.MarkRedisPersistenceNotConfigured()
+		return nil
+	}
+
+	desired := buildRedisPersistentVolumeClaim(rb)
+	fullname := types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}
+	current, err := r.pvcLister.PersistentVolumeClaims(desired.Namespace).Get(desired.Name)
+	switch {
+	case err == nil:
+		// Most of the claim spec is immutable, only growing the requested
+		// storage is supported. An empty storage class is filled with the
+		// cluster default and not considered a change.
+		scChanged := desired.Spec.StorageClassName != nil &&
+			!equality.Semantic.DeepEqual(desired.Spec.StorageClassName, current.Spec.StorageClassName)
+		amChanged := !equality.Semantic.DeepEqual(desired.Spec.AccessModes, current.Spec.AccessModes)
+		if scChanged || amChanged {
+			rb.Status.MarkRedisPersistenceFailed(common.ReasonPersistentVolumeClaimImmutable,
+				"Storage class and access mode cannot be changed for the existing Redis PersistentVolumeClaim")
+
+			return controller.NewPermanentError(pkgreconciler.NewEvent(corev1.EventTypeWarning, common.ReasonPersistentVolumeClaimImmutable,
+				"Storage class and access mode cannot be changed for Redis PersistentVolumeClaim %s", fullname))
+		}
+
+		desiredSize := desired.Spec.Resources.Requests[corev1.ResourceStorage]
+		currentSize := current.Spec.Resources.Requests[corev1.ResourceStorage]
+		if desiredSize.Cmp(currentSize) > 0 {
+			pvc := current.DeepCopy()
+			pvc.Spec.Resources.Requests[corev1.ResourceStorage] = desiredSize
+
+			current, err = r.client.CoreV1().PersistentVolumeClaims(pvc.Namespace).Update(ctx, pvc, metav1.UpdateOptions{})
+			if err != nil {
+				logging.FromContext(ctx).Error("Unable to update the persistent volume claim", zap.String("pvc", fullname.String()), zap.Error(err))
+				rb.Status.MarkRedisPersistenceFailed(common.ReasonFailedPersistentVolumeClaimUpdate, "Failed to update Redis PersistentVolumeClaim")
+
+				return pkgreconciler.NewEvent(corev1.EventTypeWarning, common.ReasonFailedPersistentVolumeClaimUpdate,
+					"Failed to update Redis PersistentVolumeClaim %s: %w", fullname, err)
+			}
+		}
+
+	case !apierrs.IsNotFound(err):
+		// An error occurred retrieving current object.
+		logging.FromContext(ctx).Error("Unable to get the persistent volume claim", zap.String("pvc", fullname.String()), zap.Error(err))
+		rb.Status.MarkRedisPersistenceFailed(common.ReasonFailedPersistentVolumeClaimGet, "Failed to get Redis PersistentVolumeClaim")
+
+		return pkgreconciler.NewEvent(corev1.EventTypeWarning, common.ReasonFailedPersistentVolumeClaimGet,
+			"Failed to get Redis PersistentVolumeClaim %s: %w", fullname, err)
+
+	default:
+		// The object has not been found, create it.
+		current, err = r.client.CoreV1().PersistentVolumeClaims(desired.Namespace).Create(ctx, desired, metav1.CreateOptions{})
+		if err != nil {
+			logging.FromContext(ctx).Error("Unable to create the persistent volume claim", zap.String("pvc", fullname.String()), zap.Error(err))
+			rb.Status.MarkRedisPersistenceFailed(common.ReasonFailedPersistentVolumeClaimCreate, "Failed to create Redis PersistentVolumeClaim")
+
+			return pkgreconciler.NewEvent(corev1.EventTypeWarning, common.ReasonFailedPersistentVolumeClaimCreate,
+				"Failed to create Redis PersistentVolumeClaim %s: %w", fullname, err)
+		}
+	}
+
+	rb.Status.PropagateRedisPersistentVolumeClaimStatus(&current.Status)
+
+	return nil
+}
+
 func buildRedisService(rb *eventingv1alpha1.RedisBroker) *corev1.Service {
 	return resources.NewService(rb.Namespace, rb.Name+"-"+redisResourceSuffix,
 		resources.ServiceWithMetaOptions(
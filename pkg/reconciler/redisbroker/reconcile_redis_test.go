@@ -0,0 +1,66 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package redisbroker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	tresources "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing/resources"
+	tmtv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing/v1alpha1"
+)
+
+func TestBuildRedisDeploymentPersistence(t *testing.T) {
+	testCases := map[string]struct {
+		persistence      *eventingv1alpha1.RedisPersistence
+		expectedStrategy appsv1.DeploymentStrategyType
+	}{
+		"no persistence": {
+			expectedStrategy: appsv1.RollingUpdateDeploymentStrategyType,
+		},
+		"persistence": {
+			persistence: &eventingv1alpha1.RedisPersistence{
+				Size:       resource.MustParse("1Gi"),
+				AccessMode: corev1.ReadWriteOnce,
+			},
+			expectedStrategy: appsv1.RecreateDeploymentStrategyType,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rb := tmtv1alpha1.NewRedisBroker(tresources.TestNamespace, tresources.TestName)
+			rb.Spec.Redis = &eventingv1alpha1.Redis{Persistence: tc.persistence}
+
+			d := buildRedisDeployment(rb, "redis")
+			assert.Equal(t, tc.expectedStrategy, d.Spec.Strategy.Type)
+
+			ps := d.Spec.Template.Spec
+			require.Len(t, ps.Containers, 1)
+			if tc.persistence == nil {
+				assert.Empty(t, ps.Volumes)
+				assert.Empty(t, ps.Containers[0].VolumeMounts)
+				return
+			}
+
+			require.Len(t, ps.Volumes, 1)
+			require.NotNil(t, ps.Volumes[0].PersistentVolumeClaim)
+			assert.Equal(t, redisPersistentVolumeClaimName(rb), ps.Volumes[0].PersistentVolumeClaim.ClaimName)
+			require.Len(t, ps.Containers[0].VolumeMounts, 1)
+			assert.Equal(t, redisDataPath, ps.Containers[0].VolumeMounts[0].MountPath)
+
+			pvc := buildRedisPersistentVolumeClaim(rb)
+			assert.Equal(t, redisPersistentVolumeClaimName(rb), pvc.Name)
+			assert.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}, pvc.Spec.AccessModes)
+			assert.True(t, tc.persistence.Size.Equal(pvc.Spec.Resources.Requests[corev1.ResourceStorage]))
+		})
+	}
+}
@@ -8,15 +8,16 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 
-	"knative.dev/pkg/apis"
 	"knative.dev/pkg/logging"
-	"knative.dev/pkg/network"
 	knreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/resolver"
 
 	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
 	"github.com/triggermesh/triggermesh-core/pkg/reconciler/common"
@@ -24,7 +25,7 @@ import (
 )
 
 const (
-	defaultMaxLen = "1000"
+	defaultMaxLen = 1000
 )
 
 type reconciler struct {
@@ -33,7 +34,26 @@ type reconciler struct {
 	saReconciler        common.ServiceAccountReconciler
 	brokerReconciler    common.BrokerReconciler
 
-	redisReconciler redisReconciler
+	// referencesReconciler is set after the controller implementation
+	// is created, since it depends on its tracker.
+	referencesReconciler common.ReferencesReconciler
+
+	redisReconciler             redisReconciler
+	redisConnectionChecker      redisConnectionChecker
+	redisStreamStatusReconciler redisStreamStatusReconciler
+	redisStreamTrimmer          redisStreamTrimmer
+	redisCleaner                *redisCleaner
+	triggerFinalizer            triggerFinalizer
+
+	// streamStatusResyncPeriod is the interval for refreshing the stream
+	// status, disabled when zero.
+	streamStatusResyncPeriod time.Duration
+	// streamRetentionPeriod is the interval for trimming streams that
+	// set retention limits, disabled when zero.
+	streamRetentionPeriod time.Duration
+	enqueueAfter          func(interface{}, time.Duration)
+
+	uriResolver *resolver.URIResolver
 }
 
 // options that set Broker environment variables specific for the RedisBroker.
@@ -47,19 +67,9 @@ func redisDeploymentOption(rb *eventingv1alpha1.RedisBroker, redisSvc *corev1.Se
 
 		c := &d.Spec.Template.Spec.Containers[0]
 
-		var stream string
-		if rb.Spec.Redis != nil && rb.Spec.Redis.Stream != nil && *rb.Spec.Redis.Stream != "" {
-			stream = *rb.Spec.Redis.Stream
-		} else {
-			stream = rb.Namespace + "." + rb.Name
-		}
-		resources.ContainerAddEnvFromValue("REDIS_STREAM", stream)(c)
+		resources.ContainerAddEnvFromValue("REDIS_STREAM", redisStreamName(rb))(c)
 
-		maxLen := defaultMaxLen
-		if rb.Spec.Redis != nil && rb.Spec.Redis.StreamMaxLen != nil {
-			maxLen = strconv.Itoa(*rb.Spec.Redis.StreamMaxLen)
-		}
-		resources.ContainerAddEnvFromValue("REDIS_STREAM_MAX_LEN", maxLen)(c)
+		resources.ContainerAddEnvFromValue("REDIS_STREAM_MAX_LEN", strconv.Itoa(redisStreamMaxLen(rb)))(c)
 
 		if rb.Spec.Redis != nil && rb.Spec.Redis.EnableTrackingID != nil && *rb.Spec.Redis.EnableTrackingID {
 			resources.ContainerAddEnvFromValue("REDIS_TRACKING_ID_ENABLED", "true")(c)
@@ -112,12 +122,7 @@ func redisDeploymentOption(rb *eventingv1alpha1.RedisBroker, redisSvc *corev1.Se
 			}
 
 			if rb.Spec.Redis.Connection.TLSSkipVerify != nil && *rb.Spec.Redis.Connection.TLSSkipVerify {
-				tlsSkipVerifyDefault := "true"
-				// TODO this should be moved to webhook
-				if rb.Spec.Redis.Connection.TLSCACertificate != nil {
-					tlsSkipVerifyDefault = "false"
-				}
-				resources.ContainerAddEnvFromValue("REDIS_TLS_SKIP_VERIFY", tlsSkipVerifyDefault)(c)
+				resources.ContainerAddEnvFromValue("REDIS_TLS_SKIP_VERIFY", "true")(c)
 			}
 
 		} else {
@@ -127,15 +132,84 @@ func redisDeploymentOption(rb *eventingv1alpha1.RedisBroker, redisSvc *corev1.Se
 	}
 }
 
+// redisStreamName returns the Redis stream used by the broker.
+func redisStreamName(rb *eventingv1alpha1.RedisBroker) string {
+	if rb.Spec.Redis != nil && rb.Spec.Redis.Stream != nil && *rb.Spec.Redis.Stream != "" {
+		return *rb.Spec.Redis.Stream
+	}
+	return rb.Namespace + "." + rb.Name
+}
+
+// redisStreamMaxLen returns the maximum number of entries at the stream,
+// zero meaning unlimited.
+func redisStreamMaxLen(rb *eventingv1alpha1.RedisBroker) int {
+	if rb.Spec.Redis != nil && rb.Spec.Redis.StreamMaxLen != nil {
+		return *rb.Spec.Redis.StreamMaxLen
+	}
+	return defaultMaxLen
+}
+
+// redisSecretReferences returns the Secret keys referenced from the user provided
+// Redis connection.
+func redisSecretReferences(rb *eventingv1alpha1.RedisBroker) []corev1.SecretKeySelector {
+	if !rb.IsUserProvidedRedis() {
+		return nil
+	}
+
+	var refs []corev1.SecretKeySelector
+	conn := rb.Spec.Redis.Connection
+	for _, s := range []*eventingv1alpha1.SecretValueFromSource{
+		conn.Username,
+		conn.Password,
+		conn.TLSCACertificate,
+		conn.TLSCertificate,
+		conn.TLSKey,
+	} {
+		if s != nil {
+			refs = append(refs, s.SecretKeyRef)
+		}
+	}
+
+	return refs
+}
+
 func (r *reconciler) ReconcileKind(ctx context.Context, rb *eventingv1alpha1.RedisBroker) knreconciler.Event {
 	logging.FromContext(ctx).Infow("Reconciling", zap.Any("RedisBroker", *rb))
 
+	// Brokers created before the webhook was deployed might not be defaulted.
+	rb.SetDefaults(ctx)
+
+	// Triggers are released before anything else so that a failure
+	// reconciling the broker does not hold their deletion.
+	if err := r.triggerFinalizer.reconcile(ctx, rb); err != nil {
+		return err
+	}
+
 	// Make sure the Redis deployment and service exists.
 	_, redisSvc, err := r.redisReconciler.reconcile(ctx, rb)
 	if err != nil {
 		return err
 	}
 
+	// Track referenced objects so that changes roll out the Broker pods.
+	// Tracking is done before checking the Redis connection so that fixing
+	// referenced credentials triggers a new check.
+	refsOption, err := r.referencesReconciler.Reconcile(ctx, rb, redisSecretReferences(rb)...)
+	if err != nil {
+		return err
+	}
+
+	// Check that user provided Redis instances can be reached. The result is
+	// informed at the RedisReachable condition and does not hold the rest of
+	// the broker objects, which need to be rolled out for the broker to
+	// connect once Redis is available.
+	connErr := r.redisConnectionChecker.reconcile(ctx, rb)
+
+	// Resolve the broker level DLS before rendering the configuration.
+	if err := common.ResolveBrokerDeadLetterSink(ctx, r.uriResolver, rb); err != nil {
+		return err
+	}
+
 	// Iterate triggers and make sure the secret contains them.
 	secret, err := r.secretReconciler.Reconcile(ctx, rb)
 	if err != nil {
@@ -155,23 +229,53 @@ func (r *reconciler) ReconcileKind(ctx context.Context, rb *eventingv1alpha1.Red
 	}
 
 	// Make sure the Broker deployment exists and that it points to the Redis service.
-	_, brokerSvc, err := r.brokerReconciler.Reconcile(ctx, rb, sa, secret, configMap, redisDeploymentOption(rb, redisSvc))
+	_, brokerSvc, err := r.brokerReconciler.Reconcile(ctx, rb, sa, secret, configMap, redisDeploymentOption(rb, redisSvc), refsOption)
 	if err != nil {
 		return err
 	}
 
-	// Set address to the Broker service.
-	rb.Status.SetAddress(getServiceAddress(brokerSvc))
+	// Set addresses to the Broker service.
+	address, addresses := common.BrokerAddresses(brokerSvc)
+	rb.Status.SetAddress(address)
+	rb.Status.Addresses = addresses
 
-	return nil
+	// Retention limits not supported by the broker are enforced by
+	// trimming the stream periodically.
+	if r.streamRetentionPeriod > 0 && hasRedisRetention(rb) {
+		r.redisStreamTrimmer.reconcile(ctx, rb, redisSvc)
+		r.enqueueAfter(rb, r.streamRetentionPeriod)
+	}
+
+	// Report the stream and consumer groups state, which is refreshed
+	// periodically since Redis changes are not notified to the controller.
+	// Refreshing only when due avoids the status update re-enqueueing the
+	// broker right away.
+	due, next := streamStatusRefreshDue(rb, r.streamStatusResyncPeriod, time.Now())
+	if due {
+		r.redisStreamStatusReconciler.reconcile(ctx, rb, redisSvc)
+	}
+	if next > 0 {
+		r.enqueueAfter(rb, next)
+	}
+
+	return connErr
 }
 
-func getServiceAddress(svc *corev1.Service) *apis.URL {
-	var port string
-	if svc.Spec.Ports[0].Port != 80 {
-		port = ":" + strconv.Itoa(int(svc.Spec.Ports[0].Port))
+// FinalizeKind removes the stream and consumer groups from the user provided
+// Redis when the deletion policy is Delete, and releases the broker's Triggers.
+func (r *reconciler) FinalizeKind(ctx context.Context, rb *eventingv1alpha1.RedisBroker) knreconciler.Event {
+	if err := r.triggerFinalizer.releaseAll(ctx, types.NamespacedName{Namespace: rb.Namespace, Name: rb.Name}); err != nil {
+		return err
 	}
 
-	return apis.HTTP(
-		network.GetServiceHostname(svc.Name, svc.Namespace) + port)
+	if !rb.IsRedisDeletionPolicyDelete() {
+		return nil
+	}
+
+	return r.redisCleaner.deleteStream(ctx, rb)
+}
+
+// ObserveDeletion releases Triggers that are deleted after their broker is gone.
+func (r *reconciler) ObserveDeletion(ctx context.Context, key types.NamespacedName) error {
+	return r.triggerFinalizer.releaseAll(ctx, key)
 }
@@ -0,0 +1,99 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package redisbroker
+
+import (
+	"context"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	corev1 "k8s.io/api/core/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	"github.com/triggermesh/triggermesh-core/pkg/reconciler/common"
+)
+
+// redisCleaner removes the broker's stream and consumer groups from user
+// provided Redis instances. Managed Redis instances are removed along with
+// the broker and do not need cleaning.
+type redisCleaner struct {
+	secretLister corev1listers.SecretLister
+	timeout      time.Duration
+}
+
+// deleteStream removes the broker's stream, which also removes all
+// consumer groups created on it.
+func (c *redisCleaner) deleteStream(ctx context.Context, rb *eventingv1alpha1.RedisBroker) pkgreconciler.Event {
+	stream := redisStreamName(rb)
+
+	return c.withClient(ctx, rb, func(ctx context.Context, client goredis.UniversalClient) pkgreconciler.Event {
+		if err := client.Del(ctx, stream).Err(); err != nil {
+			return pkgreconciler.NewEvent(corev1.EventTypeWarning, common.ReasonFailedRedisStreamDelete,
+				"Failed to delete Redis stream %q: %w", stream, err)
+		}
+
+		return pkgreconciler.NewEvent(corev1.EventTypeNormal, common.ReasonRedisStreamDeleted,
+			"Redis stream %q deleted", stream)
+	})
+}
+
+// removeConsumerGroup destroys the consumer group for the Trigger
+// configuration key.
+func (c *redisCleaner) removeConsumerGroup(ctx context.Context, rb *eventingv1alpha1.RedisBroker, triggerKey string) pkgreconciler.Event {
+	stream := redisStreamName(rb)
+	group := redisConsumerGroupPrefix + triggerKey
+
+	return c.withClient(ctx, rb, func(ctx context.Context, client goredis.UniversalClient) pkgreconciler.Event {
+		// Missing streams are reported as errors, there is nothing to remove.
+		if err := client.XGroupDestroy(ctx, stream, group).Err(); err != nil && !isRedisXGroupNoSuchKey(err) {
+			return pkgreconciler.NewEvent(corev1.EventTypeWarning, common.ReasonFailedRedisConsumerGroupDelete,
+				"Failed to delete Redis consumer group %q at stream %q: %w", group, stream, err)
+		}
+
+		return pkgreconciler.NewEvent(corev1.EventTypeNormal, common.ReasonRedisConsumerGroupDeleted,
+			"Redis consumer group %q deleted from stream %q", group, stream)
+	})
+}
+
+// withClient runs the cleanup function using a client connected to the
+// user provided Redis. When the connection credentials are not usable the
+// cleanup is skipped, a warning is recorded, and nil is returned so that the
+// deletion is not blocked.
+func (c *redisCleaner) withClient(ctx context.Context, rb *eventingv1alpha1.RedisBroker,
+	cleanup func(context.Context, goredis.UniversalClient) pkgreconciler.Event) pkgreconciler.Event {
+	logger := logging.FromContext(ctx)
+
+	client, err := newRedisClient(rb, c.secretLister, "")
+	if err != nil {
+		// Credentials might have been removed or modified before the broker,
+		// like when deleting the namespace. Retrying would block the deletion
+		// forever.
+		logger.Warnw("Redis resources were not removed, the connection credentials are not usable", zap.Error(err))
+		if recorder := controller.GetEventRecorder(ctx); recorder != nil {
+			recorder.Eventf(rb, corev1.EventTypeWarning, common.ReasonRedisCleanupSkipped,
+				"Redis resources were not removed, the connection credentials are not usable: %v", err)
+		}
+		return nil
+	}
+	defer func() {
+		if err := client.Close(); err != nil {
+			logger.Warnw("Unable to close Redis connection", zap.Error(err))
+		}
+	}()
+
+	timeout := c.timeout
+	if timeout == 0 {
+		timeout = defaultRedisConnectionTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return cleanup(ctx, client)
+}
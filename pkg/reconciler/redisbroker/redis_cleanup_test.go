@@ -0,0 +1,177 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package redisbroker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/ptr"
+	pkgreconciler "knative.dev/pkg/reconciler"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	"github.com/triggermesh/triggermesh-core/pkg/reconciler/common"
+	tmt "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing"
+	tresources "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing/resources"
+	tmtv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing/v1alpha1"
+)
+
+func TestRedisCleaner(t *testing.T) {
+	stream := tresources.TestNamespace + "." + tresources.TestName
+
+	credentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: tresources.TestNamespace,
+			Name:      "credentials",
+		},
+		Data: map[string][]byte{
+			"ca.crt": []byte("not a PEM certificate"),
+		},
+	}
+	secretKey := func(name, key string) *eventingv1alpha1.SecretValueFromSource {
+		return &eventingv1alpha1.SecretValueFromSource{
+			SecretKeyRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: name},
+				Key:                  key,
+			},
+		}
+	}
+
+	testCases := map[string]struct {
+		createStream bool
+		connection   eventingv1alpha1.RedisConnection
+		cleanup      func(context.Context, *redisCleaner, *eventingv1alpha1.RedisBroker) pkgreconciler.Event
+
+		// Skipped cleanups only record a warning event.
+		expectedSkipped bool
+		expectedReason  string
+		expectedStream  bool
+		expectedGroups  []string
+	}{
+		"delete stream": {
+			createStream: true,
+			cleanup: func(ctx context.Context, c *redisCleaner, rb *eventingv1alpha1.RedisBroker) pkgreconciler.Event {
+				return c.deleteStream(ctx, rb)
+			},
+			expectedReason: common.ReasonRedisStreamDeleted,
+		},
+		"remove trigger consumer group": {
+			createStream: true,
+			cleanup: func(ctx context.Context, c *redisCleaner, rb *eventingv1alpha1.RedisBroker) pkgreconciler.Event {
+				return c.removeConsumerGroup(ctx, rb, "trigger")
+			},
+			expectedReason: common.ReasonRedisConsumerGroupDeleted,
+			expectedStream: true,
+			expectedGroups: []string{"default.other-ns/trigger"},
+		},
+		"remove trigger consumer group from missing stream": {
+			cleanup: func(ctx context.Context, c *redisCleaner, rb *eventingv1alpha1.RedisBroker) pkgreconciler.Event {
+				return c.removeConsumerGroup(ctx, rb, "trigger")
+			},
+			expectedReason: common.ReasonRedisConsumerGroupDeleted,
+		},
+		"credentials not available": {
+			createStream: true,
+			connection: eventingv1alpha1.RedisConnection{
+				Password: secretKey("missing", "password"),
+			},
+			cleanup: func(ctx context.Context, c *redisCleaner, rb *eventingv1alpha1.RedisBroker) pkgreconciler.Event {
+				return c.deleteStream(ctx, rb)
+			},
+			expectedSkipped: true,
+			expectedStream:  true,
+			expectedGroups:  []string{"default.other-ns/trigger", "default.trigger"},
+		},
+		"credentials key not available": {
+			createStream: true,
+			connection: eventingv1alpha1.RedisConnection{
+				Password: secretKey("credentials", "password"),
+			},
+			cleanup: func(ctx context.Context, c *redisCleaner, rb *eventingv1alpha1.RedisBroker) pkgreconciler.Event {
+				return c.deleteStream(ctx, rb)
+			},
+			expectedSkipped: true,
+			expectedStream:  true,
+			expectedGroups:  []string{"default.other-ns/trigger", "default.trigger"},
+		},
+		"invalid credentials": {
+			createStream: true,
+			connection: eventingv1alpha1.RedisConnection{
+				TLSEnabled:       ptr.Bool(true),
+				TLSCACertificate: secretKey("credentials", "ca.crt"),
+			},
+			cleanup: func(ctx context.Context, c *redisCleaner, rb *eventingv1alpha1.RedisBroker) pkgreconciler.Event {
+				return c.removeConsumerGroup(ctx, rb, "trigger")
+			},
+			expectedSkipped: true,
+			expectedStream:  true,
+			expectedGroups:  []string{"default.other-ns/trigger", "default.trigger"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			m := miniredis.RunT(t)
+			client := goredis.NewClient(&goredis.Options{Addr: m.Addr()})
+			defer client.Close()
+
+			ctx := context.Background()
+			if tc.createStream {
+				for _, g := range []string{"default.trigger", "default.other-ns/trigger"} {
+					require.NoError(t, client.XGroupCreateMkStream(ctx, stream, g, "0").Err())
+				}
+			}
+
+			addr := m.Addr()
+			rb := tmtv1alpha1.NewRedisBroker(tresources.TestNamespace, tresources.TestName)
+			conn := tc.connection
+			conn.URL = &addr
+			rb.Spec.Redis = &eventingv1alpha1.Redis{
+				Connection:     &conn,
+				DeletionPolicy: eventingv1alpha1.RedisDeletionPolicyDelete,
+			}
+
+			ls := tmt.NewListers([]runtime.Object{rb, credentials})
+			c := &redisCleaner{secretLister: ls.GetSecretLister()}
+
+			recorder := record.NewFakeRecorder(1)
+			ev := tc.cleanup(controller.WithEventRecorder(ctx, recorder), c, rb)
+
+			if tc.expectedSkipped {
+				assert.NoError(t, ev, "skipped cleanups should not block the deletion")
+				require.Len(t, recorder.Events, 1)
+				assert.Contains(t, <-recorder.Events, corev1.EventTypeWarning+" "+common.ReasonRedisCleanupSkipped)
+			} else {
+				var rev *pkgreconciler.ReconcilerEvent
+				require.True(t, pkgreconciler.EventAs(ev, &rev), "cleanup should return an event")
+				assert.Equal(t, corev1.EventTypeNormal, rev.EventType, rev.Error())
+				assert.Equal(t, tc.expectedReason, rev.Reason, rev.Error())
+				assert.Empty(t, recorder.Events)
+			}
+
+			assert.Equal(t, tc.expectedStream, m.Exists(stream))
+			if !tc.expectedStream {
+				return
+			}
+
+			groups, err := client.XInfoGroups(ctx, stream).Result()
+			require.NoError(t, err)
+			var names []string
+			for _, g := range groups {
+				names = append(names, g.Name)
+			}
+			assert.ElementsMatch(t, tc.expectedGroups, names)
+		})
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package redisbroker
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	corev1 "k8s.io/api/core/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	"github.com/triggermesh/triggermesh-core/pkg/reconciler/common"
+)
+
+const (
+	defaultRedisConnectionTimeout = 5 * time.Second
+)
+
+// redisConnectionChecker verifies that user provided Redis instances can be
+// reached using the connection parameters and credentials at the RedisBroker.
+type redisConnectionChecker struct {
+	secretLister corev1listers.SecretLister
+	timeout      time.Duration
+}
+
+// reconcile connects to the user provided Redis, authenticates, and
+// inspects the broker stream, informing the result at the RedisReachable
+// condition.
+func (c *redisConnectionChecker) reconcile(ctx context.Context, rb *eventingv1alpha1.RedisBroker) error {
+	if !rb.IsUserProvidedRedis() {
+		rb.Status.MarkRedisReachableNotChecked()
+		return nil
+	}
+
+	client, err := newRedisClient(rb, c.secretLister, "")
+	if err != nil {
+		rb.Status.MarkRedisReachableFailed(common.ReasonRedisConnectionInvalid, "Invalid Redis connection parameters: %v", err)
+		return pkgreconciler.NewEvent(corev1.EventTypeWarning, common.ReasonRedisConnectionInvalid,
+			"Invalid Redis connection parameters: %w", err)
+	}
+	defer func() {
+		if err := client.Close(); err != nil {
+			logging.FromContext(ctx).Warnw("Unable to close Redis connection", zap.Error(err))
+		}
+	}()
+
+	timeout := c.timeout
+	if timeout == 0 {
+		timeout = defaultRedisConnectionTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		reason := redisErrorReason(err)
+		rb.Status.MarkRedisReachableFailed(reason, "Unable to connect to Redis: %v", err)
+		return pkgreconciler.NewEvent(corev1.EventTypeWarning, reason,
+			"Unable to connect to Redis: %w", err)
+	}
+
+	stream := redisStreamName(rb)
+	if err := client.XInfoStream(ctx, stream).Err(); err != nil {
+		switch {
+		case isRedisNoSuchKey(err):
+			// The stream is created by the broker when it starts.
+			rb.Status.MarkRedisReachableWithReason(common.ReasonRedisStreamNotFound,
+				"Redis is reachable, stream %q will be created by the broker", stream)
+			return nil
+		case strings.HasPrefix(err.Error(), "WRONGTYPE"):
+			rb.Status.MarkRedisReachableFailed(common.ReasonRedisStreamInvalid, "Redis key %q is not a stream", stream)
+			return pkgreconciler.NewEvent(corev1.EventTypeWarning, common.ReasonRedisStreamInvalid,
+				"Redis key %q is not a stream", stream)
+		default:
+			reason := redisErrorReason(err)
+			rb.Status.MarkRedisReachableFailed(reason, "Unable to inspect Redis stream %q: %v", stream, err)
+			return pkgreconciler.NewEvent(corev1.EventTypeWarning, reason,
+				"Unable to inspect Redis stream %q: %w", stream, err)
+		}
+	}
+
+	rb.Status.MarkRedisReachable()
+	return nil
+}
+
+// newRedisClient creates a Redis client that uses the same connection
+// parameters that the broker is configured with. The managed address is
+// used when the Redis instance is not user provided.
+func newRedisClient(rb *eventingv1alpha1.RedisBroker, secretLister corev1listers.SecretLister, managedAddress string) (goredis.UniversalClient, error) {
+	// Retries are disabled, the reconciliation is retried instead.
+	if !rb.IsUserProvidedRedis() {
+		return goredis.NewClient(&goredis.Options{
+			Addr:       managedAddress,
+			MaxRetries: -1,
+		}), nil
+	}
+
+	conn := rb.Spec.Redis.Connection
+	secretValue := func(s *eventingv1alpha1.SecretValueFromSource) (string, error) {
+		return redisSecretValue(secretLister, rb.Namespace, s)
+	}
+
+	username, err := secretValue(conn.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	password, err := secretValue(conn.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	var tlscfg *tls.Config
+	if conn.TLSEnabled != nil && *conn.TLSEnabled {
+		tlscfg = &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			InsecureSkipVerify: conn.TLSSkipVerify != nil && *conn.TLSSkipVerify,
+		}
+
+		ca, err := secretValue(conn.TLSCACertificate)
+		if err != nil {
+			return nil, err
+		}
+		if ca != "" {
+			roots := x509.NewCertPool()
+			if ok := roots.AppendCertsFromPEM([]byte(ca)); !ok {
+				return nil, errors.New("TLS CA certificate should be PEM formatted")
+			}
+			tlscfg.RootCAs = roots
+		}
+
+		cert, err := secretValue(conn.TLSCertificate)
+		if err != nil {
+			return nil, err
+		}
+		if cert != "" {
+			key, err := secretValue(conn.TLSKey)
+			if err != nil {
+				return nil, err
+			}
+
+			kp, err := tls.X509KeyPair([]byte(cert), []byte(key))
+			if err != nil {
+				return nil, fmt.Errorf("TLS key pair should be PEM formatted: %w", err)
+			}
+			tlscfg.Certificates = append(tlscfg.Certificates, kp)
+		}
+	}
+
+	if len(conn.ClusterURLs) != 0 {
+		return goredis.NewClusterClient(&goredis.ClusterOptions{
+			Addrs:      conn.ClusterURLs,
+			Username:   username,
+			Password:   password,
+			TLSConfig:  tlscfg,
+			MaxRetries: -1,
+		}), nil
+	}
+
+	return goredis.NewClient(&goredis.Options{
+		Addr:       *conn.URL,
+		Username:   username,
+		Password:   password,
+		TLSConfig:  tlscfg,
+		MaxRetries: -1,
+	}), nil
+}
+
+func redisSecretValue(secretLister corev1listers.SecretLister, namespace string, s *eventingv1alpha1.SecretValueFromSource) (string, error) {
+	if s == nil {
+		return "", nil
+	}
+
+	secret, err := secretLister.Secrets(namespace).Get(s.SecretKeyRef.Name)
+	if err != nil {
+		return "", fmt.Errorf("could not read Secret %q: %w", s.SecretKeyRef.Name, err)
+	}
+
+	v, ok := secret.Data[s.SecretKeyRef.Key]
+	if !ok {
+		return "", fmt.Errorf("secret %q does not contain key %q", s.SecretKeyRef.Name, s.SecretKeyRef.Key)
+	}
+
+	return string(v), nil
+}
+
+// redisErrorReason classifies errors returned when connecting to Redis.
+func redisErrorReason(err error) string {
+	var (
+		recordHeaderErr tls.RecordHeaderError
+		unknownAuthErr  x509.UnknownAuthorityError
+		certInvalidErr  x509.CertificateInvalidError
+		hostnameErr     x509.HostnameError
+	)
+
+	switch {
+	case errors.As(err, &recordHeaderErr),
+		errors.As(err, &unknownAuthErr),
+		errors.As(err, &certInvalidErr),
+		errors.As(err, &hostnameErr),
+		strings.HasPrefix(err.Error(), "tls:"):
+		return common.ReasonRedisTLSHandshakeFailed
+	}
+
+	msg := err.Error()
+	for _, p := range []string{"WRONGPASS", "NOAUTH", "NOPERM", "ERR invalid password", "ERR AUTH"} {
+		if strings.HasPrefix(msg, p) {
+			return common.ReasonRedisAuthenticationFailed
+		}
+	}
+
+	return common.ReasonRedisUnreachable
+}
+
+func isRedisNoSuchKey(err error) bool {
+	return strings.HasPrefix(err.Error(), "ERR no such key")
+}
+
+func isRedisXGroupNoSuchKey(err error) bool {
+	return strings.HasPrefix(err.Error(), "ERR The XGROUP subcommand requires the key to exist")
+}
@@ -0,0 +1,245 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package redisbroker
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/ptr"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	"github.com/triggermesh/triggermesh-core/pkg/reconciler/common"
+	tmt "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing"
+	tresources "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing/resources"
+	tmtv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing/v1alpha1"
+)
+
+const tSecretName = "redis"
+
+func TestRedisConnectionChecker(t *testing.T) {
+	caPEM, serverCert := newTestCertificate(t)
+
+	secretRef := func(key string) *eventingv1alpha1.SecretValueFromSource {
+		return &eventingv1alpha1.SecretValueFromSource{
+			SecretKeyRef: corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: tSecretName},
+				Key:                  key,
+			},
+		}
+	}
+
+	testCases := map[string]struct {
+		// setupServer configures the Redis stand-in. When nil the broker
+		// points to an address where nothing is listening.
+		setupServer func(*miniredis.Miniredis)
+		tls         bool
+		connection  eventingv1alpha1.RedisConnection
+
+		expectedStatus corev1.ConditionStatus
+		expectedReason string
+	}{
+		"stream exists": {
+			setupServer: func(m *miniredis.Miniredis) {
+				_, err := m.XAdd(tresources.TestNamespace+"."+tresources.TestName, "*", []string{"k", "v"})
+				require.NoError(t, err)
+			},
+			expectedStatus: corev1.ConditionTrue,
+		},
+		"stream not created yet": {
+			setupServer:    func(m *miniredis.Miniredis) {},
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: common.ReasonRedisStreamNotFound,
+		},
+		"key is not a stream": {
+			setupServer: func(m *miniredis.Miniredis) {
+				require.NoError(t, m.Set(tresources.TestNamespace+"."+tresources.TestName, "value"))
+			},
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: common.ReasonRedisStreamInvalid,
+		},
+		"unreachable": {
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: common.ReasonRedisUnreachable,
+		},
+		"valid credentials": {
+			setupServer: func(m *miniredis.Miniredis) {
+				m.RequireUserAuth("user", "s3cr3t")
+			},
+			connection: eventingv1alpha1.RedisConnection{
+				Username: secretRef("username"),
+				Password: secretRef("password"),
+			},
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: common.ReasonRedisStreamNotFound,
+		},
+		"wrong credentials": {
+			setupServer: func(m *miniredis.Miniredis) {
+				m.RequireUserAuth("user", "other")
+			},
+			connection: eventingv1alpha1.RedisConnection{
+				Username: secretRef("username"),
+				Password: secretRef("password"),
+			},
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: common.ReasonRedisAuthenticationFailed,
+		},
+		"missing credentials": {
+			setupServer: func(m *miniredis.Miniredis) {
+				m.RequireAuth("s3cr3t")
+			},
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: common.ReasonRedisAuthenticationFailed,
+		},
+		"missing secret key": {
+			setupServer: func(m *miniredis.Miniredis) {},
+			connection: eventingv1alpha1.RedisConnection{
+				Password: secretRef("missing"),
+			},
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: common.ReasonRedisConnectionInvalid,
+		},
+		"TLS with CA": {
+			setupServer: func(m *miniredis.Miniredis) {},
+			tls:         true,
+			connection: eventingv1alpha1.RedisConnection{
+				TLSEnabled:       ptr.Bool(true),
+				TLSCACertificate: secretRef("ca.crt"),
+			},
+			expectedStatus: corev1.ConditionTrue,
+			expectedReason: common.ReasonRedisStreamNotFound,
+		},
+		"TLS with unknown CA": {
+			setupServer: func(m *miniredis.Miniredis) {},
+			tls:         true,
+			connection: eventingv1alpha1.RedisConnection{
+				TLSEnabled: ptr.Bool(true),
+			},
+			expectedStatus: corev1.ConditionFalse,
+			expectedReason: common.ReasonRedisTLSHandshakeFailed,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var addr string
+			if tc.setupServer != nil {
+				m := miniredis.NewMiniRedis()
+				if tc.tls {
+					require.NoError(t, m.StartTLS(&tls.Config{Certificates: []tls.Certificate{serverCert}}))
+				} else {
+					require.NoError(t, m.Start())
+				}
+				t.Cleanup(m.Close)
+				tc.setupServer(m)
+				addr = m.Addr()
+			} else {
+				addr = unusedAddress(t)
+			}
+
+			conn := tc.connection
+			conn.URL = &addr
+
+			rb := tmtv1alpha1.NewRedisBroker(tresources.TestNamespace, tresources.TestName)
+			rb.Spec.Redis = &eventingv1alpha1.Redis{Connection: &conn}
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: tresources.TestNamespace, Name: tSecretName},
+				Data: map[string][]byte{
+					"username": []byte("user"),
+					"password": []byte("s3cr3t"),
+					"ca.crt":   caPEM,
+				},
+			}
+
+			ls := tmt.NewListers([]runtime.Object{rb, secret})
+			c := &redisConnectionChecker{
+				secretLister: ls.GetSecretLister(),
+				timeout:      2 * time.Second,
+			}
+
+			err := c.reconcile(context.Background(), rb)
+			if tc.expectedStatus == corev1.ConditionFalse {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			cond := rb.Status.GetCondition(eventingv1alpha1.RedisBrokerRedisReachable)
+			require.NotNil(t, cond)
+			assert.Equal(t, tc.expectedStatus, cond.Status, cond.Message)
+			assert.Equal(t, tc.expectedReason, cond.Reason, cond.Message)
+		})
+	}
+}
+
+func TestRedisConnectionCheckerManagedRedis(t *testing.T) {
+	rb := tmtv1alpha1.NewRedisBroker(tresources.TestNamespace, tresources.TestName)
+
+	c := &redisConnectionChecker{}
+	require.NoError(t, c.reconcile(context.Background(), rb))
+
+	cond := rb.Status.GetCondition(eventingv1alpha1.RedisBrokerRedisReachable)
+	require.NotNil(t, cond)
+	assert.Equal(t, corev1.ConditionTrue, cond.Status)
+}
+
+// unusedAddress returns a local address where nothing is listening.
+func unusedAddress(t *testing.T) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+	return addr
+}
+
+// newTestCertificate returns a self-signed certificate valid for 127.0.0.1,
+// both PEM encoded and as a key pair that the Redis stand-in can serve.
+func newTestCertificate(t *testing.T) ([]byte, tls.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "redis"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+
+	return certPEM, cert
+}
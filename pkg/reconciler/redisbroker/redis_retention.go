@@ -0,0 +1,149 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package redisbroker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/rickb777/date/period"
+	"go.uber.org/zap"
+
+	corev1 "k8s.io/api/core/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"knative.dev/pkg/logging"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+)
+
+// redisStreamTrimmer enforces the stream retention limits from the
+// controller. Trimming is periodic, the stream might exceed the limits
+// until the next period.
+type redisStreamTrimmer struct {
+	secretLister corev1listers.SecretLister
+	timeout      time.Duration
+}
+
+// reconcile trims the stream when retention limits are set. Failures are
+// logged, trimming is retried at the next period.
+func (r *redisStreamTrimmer) reconcile(ctx context.Context, rb *eventingv1alpha1.RedisBroker, redisSvc *corev1.Service) {
+	if !hasRedisRetention(rb) {
+		return
+	}
+
+	logger := logging.FromContext(ctx)
+
+	client, err := newRedisStreamClient(rb, r.secretLister, redisSvc)
+	if err != nil {
+		logger.Warnw("Unable to create Redis client for trimming the stream", zap.Error(err))
+		return
+	}
+	if client == nil {
+		return
+	}
+	defer func() {
+		if err := client.Close(); err != nil {
+			logger.Warnw("Unable to close Redis connection", zap.Error(err))
+		}
+	}()
+
+	timeout := r.timeout
+	if timeout == 0 {
+		timeout = defaultRedisConnectionTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if err := trimRedisStream(ctx, client, rb, time.Now()); err != nil {
+		logger.Warnw("Unable to trim the Redis stream", zap.Error(err))
+	}
+}
+
+// hasRedisRetention returns whether the broker sets retention limits.
+func hasRedisRetention(rb *eventingv1alpha1.RedisBroker) bool {
+	return rb.Spec.Redis != nil && rb.Spec.Redis.Retention != nil
+}
+
+// trimRedisStream applies the retention limits that the broker does not
+// support: events age, stream memory usage and exact length trimming. The
+// broker keeps trimming the stream length approximately when adding events.
+func trimRedisStream(ctx context.Context, client goredis.UniversalClient, rb *eventingv1alpha1.RedisBroker, now time.Time) error {
+	if !hasRedisRetention(rb) {
+		return nil
+	}
+
+	stream := redisStreamName(rb)
+	ret := rb.Spec.Redis.Retention
+	exact := ret.Exact != nil && *ret.Exact
+
+	if maxLen := redisStreamMaxLen(rb); exact && maxLen != 0 {
+		if err := client.XTrimMaxLen(ctx, stream, int64(maxLen)).Err(); err != nil {
+			return fmt.Errorf("trimming stream by length: %w", err)
+		}
+	}
+
+	if ret.MaxAge != nil {
+		p, err := period.Parse(*ret.MaxAge)
+		if err != nil {
+			return fmt.Errorf("parsing retention max age: %w", err)
+		}
+		d, _ := p.Duration()
+
+		// Entries identifiers generated by Redis start with the
+		// milliseconds timestamp when they were added.
+		minID := strconv.FormatInt(now.Add(-d).UnixMilli(), 10)
+
+		if exact {
+			err = client.XTrimMinID(ctx, stream, minID).Err()
+		} else {
+			err = client.XTrimMinIDApprox(ctx, stream, minID, 0).Err()
+		}
+		if err != nil {
+			return fmt.Errorf("trimming stream by age: %w", err)
+		}
+	}
+
+	if ret.MaxBytes != nil {
+		usage, err := client.MemoryUsage(ctx, stream).Result()
+		switch {
+		case errors.Is(err, goredis.Nil):
+			// The stream does not exist.
+			return nil
+		case err != nil:
+			return fmt.Errorf("reading stream memory usage: %w", err)
+		}
+
+		length, err := client.XLen(ctx, stream).Result()
+		if err != nil {
+			return fmt.Errorf("reading stream length: %w", err)
+		}
+
+		if maxLen, trim := maxLenForBytes(length, usage, ret.MaxBytes.Value()); trim {
+			if exact {
+				err = client.XTrimMaxLen(ctx, stream, maxLen).Err()
+			} else {
+				err = client.XTrimMaxLenApprox(ctx, stream, maxLen, 0).Err()
+			}
+			if err != nil {
+				return fmt.Errorf("trimming stream by memory usage: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// maxLenForBytes estimates the number of entries that fit in maxBytes using
+// the average entry size, and returns whether the stream needs trimming.
+func maxLenForBytes(length, usage, maxBytes int64) (int64, bool) {
+	if usage <= maxBytes || length == 0 {
+		return length, false
+	}
+
+	return length * maxBytes / usage, true
+}
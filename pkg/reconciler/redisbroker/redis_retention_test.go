@@ -0,0 +1,135 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package redisbroker
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"knative.dev/pkg/ptr"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	tresources "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing/resources"
+	tmtv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing/v1alpha1"
+)
+
+func TestTrimRedisStream(t *testing.T) {
+	now := time.Date(2023, 6, 30, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	// Entries identifiers for events added some days ago.
+	idDaysAgo := func(days int) string {
+		return strconv.FormatInt(now.Add(-time.Duration(days)*day).UnixMilli(), 10) + "-0"
+	}
+
+	testCases := map[string]struct {
+		redis *eventingv1alpha1.Redis
+
+		expectedIDs []string
+	}{
+		"no retention": {
+			redis:       &eventingv1alpha1.Redis{},
+			expectedIDs: []string{idDaysAgo(10), idDaysAgo(5), idDaysAgo(1)},
+		},
+		"max age": {
+			redis: &eventingv1alpha1.Redis{
+				Retention: &eventingv1alpha1.RedisRetention{
+					MaxAge: ptr.String("P7D"),
+					Exact:  ptr.Bool(true),
+				},
+			},
+			expectedIDs: []string{idDaysAgo(5), idDaysAgo(1)},
+		},
+		"exact max length": {
+			redis: &eventingv1alpha1.Redis{
+				StreamMaxLen: intPtr(2),
+				Retention: &eventingv1alpha1.RedisRetention{
+					Exact: ptr.Bool(true),
+				},
+			},
+			expectedIDs: []string{idDaysAgo(5), idDaysAgo(1)},
+		},
+		"max age combined with max length": {
+			redis: &eventingv1alpha1.Redis{
+				StreamMaxLen: intPtr(1),
+				Retention: &eventingv1alpha1.RedisRetention{
+					MaxAge: ptr.String("P7D"),
+					Exact:  ptr.Bool(true),
+				},
+			},
+			expectedIDs: []string{idDaysAgo(1)},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			m := miniredis.RunT(t)
+
+			rb := tmtv1alpha1.NewRedisBroker(tresources.TestNamespace, tresources.TestName)
+			rb.Spec.Redis = tc.redis
+			stream := redisStreamName(rb)
+
+			for _, id := range []string{idDaysAgo(10), idDaysAgo(5), idDaysAgo(1)} {
+				_, err := m.XAdd(stream, id, []string{"k", "v"})
+				require.NoError(t, err)
+			}
+
+			client := goredis.NewClient(&goredis.Options{Addr: m.Addr()})
+			defer client.Close()
+
+			ctx := context.Background()
+			require.NoError(t, trimRedisStream(ctx, client, rb, now))
+
+			msgs, err := client.XRange(ctx, stream, "-", "+").Result()
+			require.NoError(t, err)
+
+			ids := make([]string, 0, len(msgs))
+			for _, msg := range msgs {
+				ids = append(ids, msg.ID)
+			}
+			assert.Equal(t, tc.expectedIDs, ids)
+		})
+	}
+}
+
+func TestMaxLenForBytes(t *testing.T) {
+	testCases := map[string]struct {
+		length, usage, maxBytes int64
+
+		expectedMaxLen int64
+		expectedTrim   bool
+	}{
+		"under limit": {
+			length: 100, usage: 1000, maxBytes: 2000,
+			expectedMaxLen: 100,
+		},
+		"over limit": {
+			length: 100, usage: 4000, maxBytes: 1000,
+			expectedMaxLen: 25,
+			expectedTrim:   true,
+		},
+		"empty stream": {
+			usage: 4000, maxBytes: 1000,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			maxLen, trim := maxLenForBytes(tc.length, tc.usage, tc.maxBytes)
+			assert.Equal(t, tc.expectedMaxLen, maxLen)
+			assert.Equal(t, tc.expectedTrim, trim)
+		})
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
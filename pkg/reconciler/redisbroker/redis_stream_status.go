@@ -0,0 +1,173 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package redisbroker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/network"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+)
+
+const (
+	// redisConsumerGroupPrefix is prepended by the broker to the Trigger
+	// configuration key when creating its consumer group.
+	redisConsumerGroupPrefix = "default."
+)
+
+// redisStreamStatusReconciler reports the state of the broker's Redis stream
+// and the Triggers' consumer groups at the RedisBroker status.
+type redisStreamStatusReconciler struct {
+	secretLister corev1listers.SecretLister
+	timeout      time.Duration
+}
+
+// reconcile queries Redis and updates the stream status. Failures are not
+// returned, the status keeps the last known values and is refreshed at the
+// next resync.
+func (r *redisStreamStatusReconciler) reconcile(ctx context.Context, rb *eventingv1alpha1.RedisBroker, redisSvc *corev1.Service) {
+	logger := logging.FromContext(ctx)
+
+	client, err := newRedisStreamClient(rb, r.secretLister, redisSvc)
+	if err != nil {
+		logger.Warnw("Unable to create Redis client for reading the stream status", zap.Error(err))
+		return
+	}
+	if client == nil {
+		return
+	}
+	defer func() {
+		if err := client.Close(); err != nil {
+			logger.Warnw("Unable to close Redis connection", zap.Error(err))
+		}
+	}()
+
+	timeout := r.timeout
+	if timeout == 0 {
+		timeout = defaultRedisConnectionTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	st, err := readRedisStreamStatus(ctx, client, redisStreamName(rb))
+	if err != nil {
+		logger.Warnw("Unable to read the Redis stream status", zap.Error(err))
+		return
+	}
+
+	st.LastRefreshTime = &metav1.Time{Time: time.Now()}
+	rb.Status.Stream = st
+}
+
+// streamStatusRefreshDue returns whether the stream status needs to be read
+// from Redis, and the time left until the next refresh. The status is always
+// read when it has not been informed yet, and afterwards only when the resync
+// period has elapsed since the last refresh.
+func streamStatusRefreshDue(rb *eventingv1alpha1.RedisBroker, period time.Duration, now time.Time) (bool, time.Duration) {
+	st := rb.Status.Stream
+	if st == nil || st.LastRefreshTime == nil || st.Name != redisStreamName(rb) {
+		return true, period
+	}
+
+	if period <= 0 {
+		return false, 0
+	}
+
+	if elapsed := now.Sub(st.LastRefreshTime.Time); elapsed < period {
+		return false, period - elapsed
+	}
+
+	return true, period
+}
+
+// newRedisStreamClient creates a client for the Redis instance that hosts the
+// broker's stream. A nil client is returned while the managed Redis service
+// is not available.
+func newRedisStreamClient(rb *eventingv1alpha1.RedisBroker, secretLister corev1listers.SecretLister, redisSvc *corev1.Service) (goredis.UniversalClient, error) {
+	var managedAddress string
+	if !rb.IsUserProvidedRedis() {
+		if redisSvc == nil || len(redisSvc.Spec.Ports) == 0 {
+			return nil, nil
+		}
+		managedAddress = fmt.Sprintf("%s:%d",
+			network.GetServiceHostname(redisSvc.Name, redisSvc.Namespace), redisSvc.Spec.Ports[0].Port)
+	}
+
+	return newRedisClient(rb, secretLister, managedAddress)
+}
+
+// readRedisStreamStatus returns the length, first and last entries of the
+// stream, and the consumer groups created by the broker for each Trigger.
+func readRedisStreamStatus(ctx context.Context, client goredis.UniversalClient, stream string) (*eventingv1alpha1.RedisStreamStatus, error) {
+	st := &eventingv1alpha1.RedisStreamStatus{
+		Name: stream,
+	}
+
+	groups, err := client.XInfoGroups(ctx, stream).Result()
+	switch {
+	case err == nil:
+	case isRedisNoSuchKey(err):
+		// The stream has not been created by the broker yet.
+		return st, nil
+	default:
+		return nil, fmt.Errorf("reading consumer groups: %w", err)
+	}
+
+	for _, g := range groups {
+		if !strings.HasPrefix(g.Name, redisConsumerGroupPrefix) {
+			continue
+		}
+
+		st.ConsumerGroups = append(st.ConsumerGroups, eventingv1alpha1.RedisConsumerGroupStatus{
+			Name:            g.Name,
+			Trigger:         strings.TrimPrefix(g.Name, redisConsumerGroupPrefix),
+			Pending:         g.Pending,
+			Lag:             g.Lag,
+			LastDeliveredID: g.LastDeliveredID,
+		})
+	}
+
+	// Keep a stable order to avoid status updates when nothing changed.
+	sort.Slice(st.ConsumerGroups, func(i, j int) bool {
+		return st.ConsumerGroups[i].Name < st.ConsumerGroups[j].Name
+	})
+
+	if st.Length, err = client.XLen(ctx, stream).Result(); err != nil {
+		return nil, fmt.Errorf("reading stream length: %w", err)
+	}
+
+	if st.Length == 0 {
+		return st, nil
+	}
+
+	first, err := client.XRangeN(ctx, stream, "-", "+", 1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("reading first stream entry: %w", err)
+	}
+	if len(first) != 0 {
+		st.FirstEntryID = first[0].ID
+	}
+
+	last, err := client.XRevRangeN(ctx, stream, "+", "-", 1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("reading last stream entry: %w", err)
+	}
+	if len(last) != 0 {
+		st.LastEntryID = last[0].ID
+	}
+
+	return st, nil
+}
@@ -0,0 +1,179 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package redisbroker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+)
+
+func TestReadRedisStreamStatus(t *testing.T) {
+	const stream = "test.stream"
+
+	testCases := map[string]struct {
+		setupServer func(*testing.T, *miniredis.Miniredis)
+
+		expectedStatus *eventingv1alpha1.RedisStreamStatus
+	}{
+		"stream not created yet": {
+			setupServer: func(*testing.T, *miniredis.Miniredis) {},
+			expectedStatus: &eventingv1alpha1.RedisStreamStatus{
+				Name: stream,
+			},
+		},
+		"stream with consumer groups": {
+			setupServer: func(t *testing.T, m *miniredis.Miniredis) {
+				for _, id := range []string{"1-1", "2-1", "3-1"} {
+					_, err := m.XAdd(stream, id, []string{"k", "v"})
+					require.NoError(t, err)
+				}
+
+				c := goredis.NewClient(&goredis.Options{Addr: m.Addr()})
+				defer c.Close()
+
+				ctx := context.Background()
+				require.NoError(t, c.XGroupCreate(ctx, stream, "default.trigger", "0").Err())
+				require.NoError(t, c.XGroupCreate(ctx, stream, "default.other-ns/trigger", "3-1").Err())
+				require.NoError(t, c.XGroupCreate(ctx, stream, "external", "0").Err())
+
+				// Deliver, without acknowledging, the first entry to the
+				// same namespace trigger.
+				require.NoError(t, c.XReadGroup(ctx, &goredis.XReadGroupArgs{
+					Group:    "default.trigger",
+					Consumer: "broker",
+					Streams:  []string{stream, ">"},
+					Count:    1,
+				}).Err())
+			},
+			expectedStatus: &eventingv1alpha1.RedisStreamStatus{
+				Name:         stream,
+				Length:       3,
+				FirstEntryID: "1-1",
+				LastEntryID:  "3-1",
+				ConsumerGroups: []eventingv1alpha1.RedisConsumerGroupStatus{
+					{
+						Name:            "default.other-ns/trigger",
+						Trigger:         "other-ns/trigger",
+						LastDeliveredID: "3-1",
+					},
+					{
+						Name:            "default.trigger",
+						Trigger:         "trigger",
+						Pending:         1,
+						LastDeliveredID: "1-1",
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			m := miniredis.RunT(t)
+			tc.setupServer(t, m)
+
+			c := goredis.NewClient(&goredis.Options{Addr: m.Addr()})
+			defer c.Close()
+
+			st, err := readRedisStreamStatus(context.Background(), c, stream)
+			require.NoError(t, err)
+
+			// The Redis stand-in does not calculate the consumer group lag
+			// the way Redis does, it is not compared.
+			for i := range st.ConsumerGroups {
+				st.ConsumerGroups[i].Lag = 0
+			}
+			assert.Equal(t, tc.expectedStatus, st)
+		})
+	}
+}
+
+func TestGetTriggerConsumerStatus(t *testing.T) {
+	rb := &eventingv1alpha1.RedisBroker{}
+	assert.Nil(t, rb.GetTriggerConsumerStatus("trigger"), "status should be empty before the stream is read")
+
+	rb.Status.Stream = &eventingv1alpha1.RedisStreamStatus{
+		ConsumerGroups: []eventingv1alpha1.RedisConsumerGroupStatus{
+			{Name: "default.trigger", Trigger: "trigger", Pending: 1, Lag: 2},
+		},
+	}
+
+	assert.Equal(t, &eventingv1alpha1.TriggerConsumerStatus{Pending: 1, Lag: 2}, rb.GetTriggerConsumerStatus("trigger"))
+	assert.Nil(t, rb.GetTriggerConsumerStatus("other"))
+}
+
+func TestStreamStatusRefreshDue(t *testing.T) {
+	const period = 30 * time.Second
+	now := time.Now()
+
+	refreshedAt := func(ago time.Duration) *eventingv1alpha1.RedisStreamStatus {
+		return &eventingv1alpha1.RedisStreamStatus{
+			Name:            "test-namespace.test-name",
+			LastRefreshTime: &metav1.Time{Time: now.Add(-ago)},
+		}
+	}
+
+	testCases := map[string]struct {
+		stream *eventingv1alpha1.RedisStreamStatus
+		period time.Duration
+
+		expectedDue  bool
+		expectedNext time.Duration
+	}{
+		"not informed": {
+			period:       period,
+			expectedDue:  true,
+			expectedNext: period,
+		},
+		"not informed, resync disabled": {
+			expectedDue: true,
+		},
+		"refreshed recently": {
+			stream:       refreshedAt(10 * time.Second),
+			period:       period,
+			expectedNext: 20 * time.Second,
+		},
+		"refreshed recently, resync disabled": {
+			stream: refreshedAt(10 * time.Second),
+		},
+		"resync period elapsed": {
+			stream:       refreshedAt(period),
+			period:       period,
+			expectedDue:  true,
+			expectedNext: period,
+		},
+		"stream renamed": {
+			stream: &eventingv1alpha1.RedisStreamStatus{
+				Name:            "other",
+				LastRefreshTime: &metav1.Time{Time: now},
+			},
+			period:       period,
+			expectedDue:  true,
+			expectedNext: period,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rb := &eventingv1alpha1.RedisBroker{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "test-name"},
+			}
+			rb.Status.Stream = tc.stream
+
+			due, next := streamStatusRefreshDue(rb, tc.period, now)
+			assert.Equal(t, tc.expectedDue, due)
+			assert.Equal(t, tc.expectedNext, next)
+		})
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package redisbroker
+
+import (
+	"context"
+	"encoding/json"
+
+	"go.uber.org/zap"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	"github.com/triggermesh/triggermesh-core/pkg/client/generated/clientset/internalclientset"
+	eventingv1alpha1listers "github.com/triggermesh/triggermesh-core/pkg/client/generated/listers/eventing/v1alpha1"
+	"github.com/triggermesh/triggermesh-core/pkg/reconciler/common"
+)
+
+// triggerFinalizerName is set on the Triggers of RedisBrokers whose deletion
+// policy is Delete, so that their consumer groups are removed from the user
+// provided Redis before the Trigger is gone.
+const triggerFinalizerName = "redisbrokers.eventing.triggermesh.io/consumer-group"
+
+// triggerFinalizer manages the finalizer at the Triggers of a RedisBroker.
+// Cleaning the consumer group is best effort, the finalizer is released
+// even when Redis cannot be reached so that Triggers are never stuck.
+type triggerFinalizer struct {
+	client        internalclientset.Interface
+	triggerLister eventingv1alpha1listers.TriggerLister
+	brokerLister  eventingv1alpha1listers.BrokerLister
+	cleaner       *redisCleaner
+}
+
+// reconcile sets the finalizer at the broker's Triggers when the deletion
+// policy is Delete, and releases it from Triggers that are being deleted,
+// removing their consumer groups first, or that no longer need it.
+func (f *triggerFinalizer) reconcile(ctx context.Context, rb *eventingv1alpha1.RedisBroker) pkgreconciler.Event {
+	ts, err := f.triggerLister.List(labels.Everything())
+	if err != nil {
+		return pkgreconciler.NewEvent(corev1.EventTypeWarning, common.ReasonFailedTriggerList,
+			"Failed to list triggers: %w", err)
+	}
+
+	for _, t := range ts {
+		if !common.TriggerMatchesBroker(t, rb, f.brokerLister) {
+			continue
+		}
+
+		has := hasTriggerFinalizer(t)
+		switch {
+		case t.DeletionTimestamp != nil:
+			if !has {
+				continue
+			}
+			if rb.IsRedisDeletionPolicyDelete() {
+				f.removeConsumerGroup(ctx, rb, t)
+			}
+			err = f.release(ctx, t)
+
+		case rb.IsRedisDeletionPolicyDelete() && !has:
+			err = f.patchFinalizers(ctx, t, append(append([]string{}, t.Finalizers...), triggerFinalizerName))
+
+		case !rb.IsRedisDeletionPolicyDelete() && has:
+			err = f.release(ctx, t)
+
+		default:
+			continue
+		}
+
+		if err != nil {
+			return pkgreconciler.NewEvent(corev1.EventTypeWarning, common.ReasonFailedTriggerFinalizersUpdate,
+				"Failed to update finalizers for trigger %s/%s: %w", t.Namespace, t.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// releaseAll releases the finalizer from all Triggers that reference the
+// broker, which is either being deleted or already gone.
+func (f *triggerFinalizer) releaseAll(ctx context.Context, broker types.NamespacedName) pkgreconciler.Event {
+	ts, err := f.triggerLister.List(labels.Everything())
+	if err != nil {
+		return pkgreconciler.NewEvent(corev1.EventTypeWarning, common.ReasonFailedTriggerList,
+			"Failed to list triggers: %w", err)
+	}
+
+	for _, t := range ts {
+		if t.BrokerNamespace() != broker.Namespace || t.Spec.Broker.Name != broker.Name ||
+			!hasTriggerFinalizer(t) {
+			continue
+		}
+
+		if err := f.release(ctx, t); err != nil {
+			return pkgreconciler.NewEvent(corev1.EventTypeWarning, common.ReasonFailedTriggerFinalizersUpdate,
+				"Failed to update finalizers for trigger %s/%s: %w", t.Namespace, t.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// removeConsumerGroup destroys the Trigger's consumer group, failures are
+// logged and do not hold the Trigger deletion.
+func (f *triggerFinalizer) removeConsumerGroup(ctx context.Context, rb *eventingv1alpha1.RedisBroker, t *eventingv1alpha1.Trigger) {
+	logger := logging.FromContext(ctx)
+
+	event := f.cleaner.removeConsumerGroup(ctx, rb, t.ConfigKey())
+	if event == nil {
+		// The cleanup was skipped and reported by the cleaner.
+		return
+	}
+	if e, ok := event.(*pkgreconciler.ReconcilerEvent); ok && e.EventType == corev1.EventTypeNormal {
+		logger.Infow(e.Error(), zap.String("trigger", t.Namespace+"/"+t.Name))
+		return
+	}
+
+	logger.Warnw("Consumer group for the trigger was not removed from Redis",
+		zap.String("trigger", t.Namespace+"/"+t.Name), zap.Error(event))
+}
+
+func (f *triggerFinalizer) release(ctx context.Context, t *eventingv1alpha1.Trigger) error {
+	finalizers := make([]string, 0, len(t.Finalizers))
+	for _, fn := range t.Finalizers {
+		if fn != triggerFinalizerName {
+			finalizers = append(finalizers, fn)
+		}
+	}
+
+	return f.patchFinalizers(ctx, t, finalizers)
+}
+
+// patchFinalizers sets the Trigger finalizers, failing if the Trigger was
+// modified since it was read.
+func (f *triggerFinalizer) patchFinalizers(ctx context.Context, t *eventingv1alpha1.Trigger, finalizers []string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"finalizers":      finalizers,
+			"resourceVersion": t.ResourceVersion,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = f.client.EventingV1alpha1().Triggers(t.Namespace).Patch(ctx, t.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+func hasTriggerFinalizer(t *eventingv1alpha1.Trigger) bool {
+	for _, fn := range t.Finalizers {
+		if fn == triggerFinalizerName {
+			return true
+		}
+	}
+	return false
+}
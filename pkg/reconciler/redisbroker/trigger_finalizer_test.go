@@ -0,0 +1,109 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package redisbroker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	fakeclientset "github.com/triggermesh/triggermesh-core/pkg/client/generated/clientset/internalclientset/fake"
+	tmt "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing"
+	tresources "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing/resources"
+	tmtv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/reconciler/testing/v1alpha1"
+)
+
+func TestTriggerFinalizer(t *testing.T) {
+	withFinalizer := func(t *eventingv1alpha1.Trigger) {
+		t.Finalizers = []string{"other", triggerFinalizerName}
+	}
+	deleted := func(t *eventingv1alpha1.Trigger) {
+		now := metav1.Now()
+		t.DeletionTimestamp = &now
+	}
+	redisBroker := func(t *eventingv1alpha1.Trigger) {
+		t.Spec.Broker.Kind = "RedisBroker"
+	}
+
+	testCases := map[string]struct {
+		deletionPolicy eventingv1alpha1.RedisDeletionPolicy
+		trigger        *eventingv1alpha1.Trigger
+		brokerGone     bool
+
+		expectedFinalizers []string
+	}{
+		"set finalizer when policy is delete": {
+			deletionPolicy:     eventingv1alpha1.RedisDeletionPolicyDelete,
+			trigger:            tmtv1alpha1.NewTrigger(tresources.TestNamespace, "trigger", tresources.TestName, redisBroker),
+			expectedFinalizers: []string{triggerFinalizerName},
+		},
+		"release finalizer when policy is retain": {
+			deletionPolicy:     eventingv1alpha1.RedisDeletionPolicyRetain,
+			trigger:            tmtv1alpha1.NewTrigger(tresources.TestNamespace, "trigger", tresources.TestName, redisBroker, withFinalizer),
+			expectedFinalizers: []string{"other"},
+		},
+		"release deleted trigger when redis is unreachable": {
+			deletionPolicy:     eventingv1alpha1.RedisDeletionPolicyDelete,
+			trigger:            tmtv1alpha1.NewTrigger(tresources.TestNamespace, "trigger", tresources.TestName, redisBroker, withFinalizer, deleted),
+			expectedFinalizers: []string{"other"},
+		},
+		"ignore triggers for other brokers": {
+			deletionPolicy: eventingv1alpha1.RedisDeletionPolicyDelete,
+			trigger:        tmtv1alpha1.NewTrigger(tresources.TestNamespace, "trigger", tresources.TestName),
+		},
+		"release triggers when broker is gone": {
+			deletionPolicy:     eventingv1alpha1.RedisDeletionPolicyDelete,
+			trigger:            tmtv1alpha1.NewTrigger(tresources.TestNamespace, "trigger", tresources.TestName, redisBroker, withFinalizer, deleted),
+			brokerGone:         true,
+			expectedFinalizers: []string{"other"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// Nothing listens at the Redis address.
+			addr := "127.0.0.1:1"
+			rb := tmtv1alpha1.NewRedisBroker(tresources.TestNamespace, tresources.TestName)
+			rb.Spec.Redis = &eventingv1alpha1.Redis{
+				Connection:     &eventingv1alpha1.RedisConnection{URL: &addr},
+				DeletionPolicy: tc.deletionPolicy,
+			}
+
+			ls := tmt.NewListers([]runtime.Object{rb, tc.trigger})
+			client := fakeclientset.NewSimpleClientset(tc.trigger)
+			f := &triggerFinalizer{
+				client:        client,
+				triggerLister: ls.GetTriggerLister(),
+				brokerLister:  ls.GetBrokerLister(),
+				cleaner: &redisCleaner{
+					secretLister: ls.GetSecretLister(),
+					timeout:      100 * time.Millisecond,
+				},
+			}
+
+			ctx := context.Background()
+			if tc.brokerGone {
+				require.NoError(t, f.releaseAll(ctx, types.NamespacedName{Namespace: rb.Namespace, Name: rb.Name}))
+			} else {
+				require.NoError(t, f.reconcile(ctx, rb))
+			}
+
+			got, err := client.EventingV1alpha1().Triggers(tc.trigger.Namespace).Get(ctx, tc.trigger.Name, metav1.GetOptions{})
+			require.NoError(t, err)
+			if tc.expectedFinalizers == nil {
+				assert.Equal(t, tc.trigger.Finalizers, got.Finalizers)
+				return
+			}
+			assert.Equal(t, tc.expectedFinalizers, got.Finalizers)
+		})
+	}
+}
@@ -108,3 +108,9 @@ func ContainerWithImagePullPolicy(policy corev1.PullPolicy) ContainerOption {
 		c.ImagePullPolicy = policy
 	}
 }
+
+func ContainerWithResources(resources corev1.ResourceRequirements) ContainerOption {
+	return func(c *corev1.Container) {
+		c.Resources = resources
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 func TestNewContainer(t *testing.T) {
@@ -106,6 +107,23 @@ func TestNewContainer(t *testing.T) {
 				Image:           tImage,
 				ImagePullPolicy: corev1.PullAlways,
 			}},
+		"with resources": {
+			options: []ContainerOption{
+				ContainerWithResources(corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("100m"),
+					},
+				}),
+			},
+			expected: corev1.Container{
+				Name:  tName,
+				Image: tImage,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("100m"),
+					},
+				},
+			}},
 	}
 
 	for name, tc := range testCases {
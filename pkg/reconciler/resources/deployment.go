@@ -120,3 +120,33 @@ func PodSpecWithServiceAccountName(saName string) PodSpecOption {
 		ps.ServiceAccountName = saName
 	}
 }
+
+func PodSpecWithNodeSelector(selector map[string]string) PodSpecOption {
+	return func(ps *corev1.PodSpec) {
+		ps.NodeSelector = selector
+	}
+}
+
+func PodSpecWithTolerations(tolerations ...corev1.Toleration) PodSpecOption {
+	return func(ps *corev1.PodSpec) {
+		ps.Tolerations = tolerations
+	}
+}
+
+func PodSpecWithAffinity(affinity *corev1.Affinity) PodSpecOption {
+	return func(ps *corev1.PodSpec) {
+		ps.Affinity = affinity
+	}
+}
+
+func PodSpecWithPriorityClassName(name string) PodSpecOption {
+	return func(ps *corev1.PodSpec) {
+		ps.PriorityClassName = name
+	}
+}
+
+func DeploymentWithStrategy(strategy appsv1.DeploymentStrategyType) DeploymentOption {
+	return func(d *appsv1.Deployment) {
+		d.Spec.Strategy.Type = strategy
+	}
+}
@@ -254,6 +254,31 @@ func TestNewPodSpec(t *testing.T) {
 			expected: corev1.PodSpec{
 				ServiceAccountName: tServiceAccountName,
 			}},
+		"with scheduling options": {
+			options: []PodSpecOption{
+				PodSpecWithNodeSelector(map[string]string{"disk": "ssd"}),
+				PodSpecWithTolerations(corev1.Toleration{
+					Key:      "dedicated",
+					Operator: corev1.TolerationOpExists,
+				}),
+				PodSpecWithAffinity(&corev1.Affinity{
+					PodAntiAffinity: &corev1.PodAntiAffinity{},
+				}),
+				PodSpecWithPriorityClassName("high"),
+			},
+			expected: corev1.PodSpec{
+				NodeSelector: map[string]string{"disk": "ssd"},
+				Tolerations: []corev1.Toleration{
+					{
+						Key:      "dedicated",
+						Operator: corev1.TolerationOpExists,
+					},
+				},
+				Affinity: &corev1.Affinity{
+					PodAntiAffinity: &corev1.PodAntiAffinity{},
+				},
+				PriorityClassName: "high",
+			}},
 	}
 
 	for name, tc := range testCases {
@@ -0,0 +1,84 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type HorizontalPodAutoscalerOption func(*autoscalingv2.HorizontalPodAutoscaler)
+
+func NewHorizontalPodAutoscaler(namespace, name string, opts ...HorizontalPodAutoscalerOption) *autoscalingv2.HorizontalPodAutoscaler {
+	meta := NewMeta(namespace, name)
+	h := &autoscalingv2.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "HorizontalPodAutoscaler",
+			APIVersion: autoscalingv2.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: *meta,
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+func HorizontalPodAutoscalerWithMetaOptions(opts ...MetaOption) HorizontalPodAutoscalerOption {
+	return func(h *autoscalingv2.HorizontalPodAutoscaler) {
+		for _, opt := range opts {
+			opt(&h.ObjectMeta)
+		}
+	}
+}
+
+// HorizontalPodAutoscalerWithDeploymentTarget sets the Deployment to be scaled.
+func HorizontalPodAutoscalerWithDeploymentTarget(name string) HorizontalPodAutoscalerOption {
+	return func(h *autoscalingv2.HorizontalPodAutoscaler) {
+		h.Spec.ScaleTargetRef = autoscalingv2.CrossVersionObjectReference{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       "Deployment",
+			Name:       name,
+		}
+	}
+}
+
+func HorizontalPodAutoscalerSetMinReplicas(replicas int32) HorizontalPodAutoscalerOption {
+	return func(h *autoscalingv2.HorizontalPodAutoscaler) {
+		h.Spec.MinReplicas = &replicas
+	}
+}
+
+func HorizontalPodAutoscalerSetMaxReplicas(replicas int32) HorizontalPodAutoscalerOption {
+	return func(h *autoscalingv2.HorizontalPodAutoscaler) {
+		h.Spec.MaxReplicas = replicas
+	}
+}
+
+// HorizontalPodAutoscalerAddCPUUtilizationMetric adds a metric that targets an
+// average CPU utilization percentage.
+func HorizontalPodAutoscalerAddCPUUtilizationMetric(percentage int32) HorizontalPodAutoscalerOption {
+	return func(h *autoscalingv2.HorizontalPodAutoscaler) {
+		h.Spec.Metrics = append(h.Spec.Metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name: corev1.ResourceCPU,
+				Target: autoscalingv2.MetricTarget{
+					Type:               autoscalingv2.UtilizationMetricType,
+					AverageUtilization: &percentage,
+				},
+			},
+		})
+	}
+}
+
+func HorizontalPodAutoscalerAddMetrics(metrics ...autoscalingv2.MetricSpec) HorizontalPodAutoscalerOption {
+	return func(h *autoscalingv2.HorizontalPodAutoscaler) {
+		h.Spec.Metrics = append(h.Spec.Metrics, metrics...)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewHorizontalPodAutoscaler(t *testing.T) {
+	min, cpu := int32(2), int32(80)
+
+	testCases := map[string]struct {
+		options  []HorizontalPodAutoscalerOption
+		expected autoscalingv2.HorizontalPodAutoscalerSpec
+	}{
+		"basic": {},
+		"with deployment target and replicas": {
+			options: []HorizontalPodAutoscalerOption{
+				HorizontalPodAutoscalerWithDeploymentTarget(tName),
+				HorizontalPodAutoscalerSetMinReplicas(2),
+				HorizontalPodAutoscalerSetMaxReplicas(5),
+			},
+			expected: autoscalingv2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+					Name:       tName,
+				},
+				MinReplicas: &min,
+				MaxReplicas: 5,
+			},
+		},
+		"with CPU and custom metrics": {
+			options: []HorizontalPodAutoscalerOption{
+				HorizontalPodAutoscalerAddCPUUtilizationMetric(80),
+				HorizontalPodAutoscalerAddMetrics(autoscalingv2.MetricSpec{
+					Type: autoscalingv2.PodsMetricSourceType,
+				}),
+			},
+			expected: autoscalingv2.HorizontalPodAutoscalerSpec{
+				Metrics: []autoscalingv2.MetricSpec{
+					{
+						Type: autoscalingv2.ResourceMetricSourceType,
+						Resource: &autoscalingv2.ResourceMetricSource{
+							Name: corev1.ResourceCPU,
+							Target: autoscalingv2.MetricTarget{
+								Type:               autoscalingv2.UtilizationMetricType,
+								AverageUtilization: &cpu,
+							},
+						},
+					},
+					{
+						Type: autoscalingv2.PodsMetricSourceType,
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := NewHorizontalPodAutoscaler(tNamespace, tName, tc.options...)
+
+			assert.Equal(t, metav1.TypeMeta{
+				Kind:       "HorizontalPodAutoscaler",
+				APIVersion: "autoscaling/v2",
+			}, got.TypeMeta)
+			assert.Equal(t, tNamespace, got.Namespace)
+			assert.Equal(t, tName, got.Name)
+			assert.Equal(t, tc.expected, got.Spec)
+		})
+	}
+}
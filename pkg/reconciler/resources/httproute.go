@@ -0,0 +1,120 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// HTTPRouteGVR is the Gateway API HTTPRoute resource.
+var HTTPRouteGVR = schema.GroupVersionResource{
+	Group:    "gateway.networking.k8s.io",
+	Version:  "v1beta1",
+	Resource: "httproutes",
+}
+
+// HTTPRoute contains the subset of the Gateway API HTTPRoute managed for
+// brokers. The Gateway API is not a dependency of this project, routes are
+// managed as unstructured objects.
+type HTTPRoute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec HTTPRouteSpec `json:"spec"`
+}
+
+type HTTPRouteSpec struct {
+	ParentRefs []HTTPRouteParentRef `json:"parentRefs,omitempty"`
+	Hostnames  []string             `json:"hostnames,omitempty"`
+	Rules      []HTTPRouteRule      `json:"rules,omitempty"`
+}
+
+type HTTPRouteParentRef struct {
+	Name        string  `json:"name"`
+	Namespace   *string `json:"namespace,omitempty"`
+	SectionName *string `json:"sectionName,omitempty"`
+}
+
+type HTTPRouteRule struct {
+	BackendRefs []HTTPRouteBackendRef `json:"backendRefs,omitempty"`
+}
+
+type HTTPRouteBackendRef struct {
+	Name string `json:"name"`
+	Port int32  `json:"port"`
+}
+
+type HTTPRouteOption func(*HTTPRoute)
+
+func NewHTTPRoute(namespace, name string, opts ...HTTPRouteOption) *HTTPRoute {
+	meta := NewMeta(namespace, name)
+	r := &HTTPRoute{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "HTTPRoute",
+			APIVersion: HTTPRouteGVR.GroupVersion().String(),
+		},
+		ObjectMeta: *meta,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+func HTTPRouteWithMetaOptions(opts ...MetaOption) HTTPRouteOption {
+	return func(r *HTTPRoute) {
+		for _, opt := range opts {
+			opt(&r.ObjectMeta)
+		}
+	}
+}
+
+func HTTPRouteAddParentRef(ref HTTPRouteParentRef) HTTPRouteOption {
+	return func(r *HTTPRoute) {
+		r.Spec.ParentRefs = append(r.Spec.ParentRefs, ref)
+	}
+}
+
+func HTTPRouteAddHostname(hostname string) HTTPRouteOption {
+	return func(r *HTTPRoute) {
+		r.Spec.Hostnames = append(r.Spec.Hostnames, hostname)
+	}
+}
+
+// HTTPRouteAddServiceRule routes all requests to the Service port.
+func HTTPRouteAddServiceRule(serviceName string, port int32) HTTPRouteOption {
+	return func(r *HTTPRoute) {
+		r.Spec.Rules = append(r.Spec.Rules, HTTPRouteRule{
+			BackendRefs: []HTTPRouteBackendRef{{
+				Name: serviceName,
+				Port: port,
+			}},
+		})
+	}
+}
+
+// HTTPRouteToUnstructured converts the route to be managed using a dynamic
+// client.
+func HTTPRouteToUnstructured(r *HTTPRoute) (*unstructured.Unstructured, error) {
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(r)
+	if err != nil {
+		return nil, err
+	}
+	return &unstructured.Unstructured{Object: obj}, nil
+}
+
+// HTTPRouteFromUnstructured reads the managed subset of a route. Fields set
+// by other actors, such as defaults, are ignored.
+func HTTPRouteFromUnstructured(u *unstructured.Unstructured) (*HTTPRoute, error) {
+	r := &HTTPRoute{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
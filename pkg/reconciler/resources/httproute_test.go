@@ -0,0 +1,47 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPRoute(t *testing.T) {
+	section := "https"
+
+	r := NewHTTPRoute(tNamespace, tName,
+		HTTPRouteWithMetaOptions(MetaAddLabel("key", "value")),
+		HTTPRouteAddParentRef(HTTPRouteParentRef{Name: "gateway", SectionName: &section}),
+		HTTPRouteAddHostname("broker.example.com"),
+		HTTPRouteAddServiceRule(tName, 80))
+
+	u, err := HTTPRouteToUnstructured(r)
+	require.NoError(t, err)
+
+	assert.Equal(t, "gateway.networking.k8s.io/v1beta1", u.GetAPIVersion())
+	assert.Equal(t, "HTTPRoute", u.GetKind())
+	assert.Equal(t, map[string]string{"key": "value"}, u.GetLabels())
+	assert.Equal(t, map[string]interface{}{
+		"parentRefs": []interface{}{
+			map[string]interface{}{"name": "gateway", "sectionName": "https"},
+		},
+		"hostnames": []interface{}{"broker.example.com"},
+		"rules": []interface{}{
+			map[string]interface{}{
+				"backendRefs": []interface{}{
+					map[string]interface{}{"name": tName, "port": int64(80)},
+				},
+			},
+		},
+	}, u.Object["spec"])
+
+	// Fields that are not managed are ignored when reading the route.
+	u.Object["status"] = map[string]interface{}{"parents": []interface{}{}}
+	got, err := HTTPRouteFromUnstructured(u)
+	require.NoError(t, err)
+	assert.Equal(t, r, got)
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewIngress(t *testing.T) {
+	className := "nginx"
+	pathType := networkingv1.PathTypePrefix
+
+	testCases := map[string]struct {
+		options  []IngressOption
+		expected networkingv1.IngressSpec
+	}{
+		"basic": {},
+		"with class and service rule": {
+			options: []IngressOption{
+				IngressSetClassName(className),
+				IngressAddServiceRule("broker.example.com", tName, "http"),
+			},
+			expected: networkingv1.IngressSpec{
+				IngressClassName: &className,
+				Rules: []networkingv1.IngressRule{{
+					Host: "broker.example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{{
+								Path:     "/",
+								PathType: &pathType,
+								Backend: networkingv1.IngressBackend{
+									Service: &networkingv1.IngressServiceBackend{
+										Name: tName,
+										Port: networkingv1.ServiceBackendPort{Name: "http"},
+									},
+								},
+							}},
+						},
+					},
+				}},
+			},
+		},
+		"with TLS": {
+			options: []IngressOption{
+				IngressAddTLS("broker-tls", "broker.example.com"),
+			},
+			expected: networkingv1.IngressSpec{
+				TLS: []networkingv1.IngressTLS{{
+					Hosts:      []string{"broker.example.com"},
+					SecretName: "broker-tls",
+				}},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := NewIngress(tNamespace, tName, tc.options...)
+
+			assert.Equal(t, metav1.TypeMeta{
+				Kind:       "Ingress",
+				APIVersion: "networking.k8s.io/v1",
+			}, got.TypeMeta)
+			assert.Equal(t, tNamespace, got.Namespace)
+			assert.Equal(t, tName, got.Name)
+			assert.Equal(t, tc.expected, got.Spec)
+		})
+	}
+}
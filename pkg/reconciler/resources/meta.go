@@ -64,3 +64,12 @@ func MetaSetDeletion(t *metav1.Time) MetaOption {
 		m.DeletionTimestamp = t
 	}
 }
+
+func MetaAddAnnotation(key, value string) MetaOption {
+	return func(m *metav1.ObjectMeta) {
+		if m.Annotations == nil {
+			m.Annotations = make(map[string]string, 1)
+		}
+		m.Annotations[key] = value
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type PersistentVolumeClaimOption func(*corev1.PersistentVolumeClaim)
+
+func NewPersistentVolumeClaim(namespace, name string, opts ...PersistentVolumeClaimOption) *corev1.PersistentVolumeClaim {
+	meta := NewMeta(namespace, name)
+	pvc := &corev1.PersistentVolumeClaim{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PersistentVolumeClaim",
+			APIVersion: corev1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: *meta,
+	}
+
+	for _, opt := range opts {
+		opt(pvc)
+	}
+
+	return pvc
+}
+
+func PersistentVolumeClaimWithMetaOptions(opts ...MetaOption) PersistentVolumeClaimOption {
+	return func(pvc *corev1.PersistentVolumeClaim) {
+		for _, opt := range opts {
+			opt(&pvc.ObjectMeta)
+		}
+	}
+}
+
+func PersistentVolumeClaimWithStorageClassName(name *string) PersistentVolumeClaimOption {
+	return func(pvc *corev1.PersistentVolumeClaim) {
+		pvc.Spec.StorageClassName = name
+	}
+}
+
+func PersistentVolumeClaimAddAccessMode(mode corev1.PersistentVolumeAccessMode) PersistentVolumeClaimOption {
+	return func(pvc *corev1.PersistentVolumeClaim) {
+		pvc.Spec.AccessModes = append(pvc.Spec.AccessModes, mode)
+	}
+}
+
+func PersistentVolumeClaimWithStorageRequest(size resource.Quantity) PersistentVolumeClaimOption {
+	return func(pvc *corev1.PersistentVolumeClaim) {
+		if pvc.Spec.Resources.Requests == nil {
+			pvc.Spec.Resources.Requests = make(corev1.ResourceList, 1)
+		}
+		pvc.Spec.Resources.Requests[corev1.ResourceStorage] = size
+	}
+}
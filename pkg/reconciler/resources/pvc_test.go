@@ -0,0 +1,67 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewPersistentVolumeClaim(t *testing.T) {
+	storageClass := "standard"
+
+	testCases := map[string]struct {
+		options  []PersistentVolumeClaimOption
+		expected corev1.PersistentVolumeClaim
+	}{
+		"basic": {
+			expected: corev1.PersistentVolumeClaim{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "PersistentVolumeClaim",
+					APIVersion: corev1.SchemeGroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: tNamespace,
+					Name:      tName,
+				},
+			}},
+		"with storage parameters": {
+			options: []PersistentVolumeClaimOption{
+				PersistentVolumeClaimWithStorageClassName(&storageClass),
+				PersistentVolumeClaimAddAccessMode(corev1.ReadWriteOnce),
+				PersistentVolumeClaimWithStorageRequest(resource.MustParse("1Gi")),
+			},
+			expected: corev1.PersistentVolumeClaim{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "PersistentVolumeClaim",
+					APIVersion: corev1.SchemeGroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: tNamespace,
+					Name:      tName,
+				},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					StorageClassName: &storageClass,
+					AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceStorage: resource.MustParse("1Gi"),
+						},
+					},
+				},
+			}},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := NewPersistentVolumeClaim(tNamespace, tName, tc.options...)
+			assert.Equal(t, &tc.expected, got)
+		})
+	}
+}
@@ -33,3 +33,11 @@ func VolumeFromSecretOption(secretName, secretKey, mountFile string) VolumeOptio
 		}
 	}
 }
+
+func VolumeFromPersistentVolumeClaimOption(claimName string) VolumeOption {
+	return func(v *corev1.Volume) {
+		v.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{
+			ClaimName: claimName,
+		}
+	}
+}
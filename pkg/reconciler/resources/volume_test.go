@@ -36,6 +36,18 @@ func TestNewVolume(t *testing.T) {
 					},
 				},
 			}},
+		"with persistent volume claim": {
+			options: []VolumeOption{
+				VolumeFromPersistentVolumeClaimOption(tName),
+			},
+			expected: corev1.Volume{
+				Name: tName,
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: tName,
+					},
+				},
+			}},
 	}
 
 	for name, tc := range testCases {
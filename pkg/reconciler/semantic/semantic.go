@@ -5,8 +5,10 @@ package semantic
 
 import (
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/conversion"
@@ -26,6 +28,8 @@ var Semantic = conversion.EqualitiesOrDie(
 	serviceEqual,
 	secretEqual,
 	jobEqual,
+	horizontalPodAutoscalerEqual,
+	ingressEqual,
 )
 
 // eq is an instance of Equalities for internal deep derivative comparisons
@@ -101,6 +105,9 @@ func deploymentEqual(a, b *appsv1.Deployment) bool {
 		return false
 	}
 
+	// Replicas are not informed at the desired state when the Deployment is
+	// scaled by a HorizontalPodAutoscaler, in which case DeepDerivative
+	// ignores the current value.
 	if !eq.DeepDerivative(&a.Spec, &b.Spec) {
 		return false
 	}
@@ -192,3 +199,53 @@ func jobEqual(a, b *batchv1.Job) bool {
 
 	return true
 }
+
+// horizontalPodAutoscalerEqual returns whether two HorizontalPodAutoscalers are semantically equivalent.
+func horizontalPodAutoscalerEqual(a, b *autoscalingv2.HorizontalPodAutoscaler) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+
+	if !eq.DeepDerivative(&a.ObjectMeta, &b.ObjectMeta) {
+		return false
+	}
+
+	// Metrics are compared as a whole, removing a metric must trigger an update.
+	if len(a.Spec.Metrics) != len(b.Spec.Metrics) {
+		return false
+	}
+
+	if !eq.DeepDerivative(&a.Spec, &b.Spec) {
+		return false
+	}
+
+	return true
+}
+
+// ingressEqual returns whether two Ingresses are semantically equivalent.
+func ingressEqual(a, b *networkingv1.Ingress) bool {
+	if a == b {
+		return true
+	}
+	if a == nil || b == nil {
+		return false
+	}
+
+	if !eq.DeepDerivative(&a.ObjectMeta, &b.ObjectMeta) {
+		return false
+	}
+
+	// TLS entries are compared as a whole, disabling TLS must trigger an update.
+	if len(a.Spec.TLS) != len(b.Spec.TLS) {
+		return false
+	}
+
+	if !eq.DeepDerivative(&a.Spec, &b.Spec) {
+		return false
+	}
+
+	return true
+}
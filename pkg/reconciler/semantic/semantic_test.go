@@ -394,6 +394,23 @@ func TestDeploymentEqual(t *testing.T) {
 			},
 			false,
 		},
+		"not equal when desired replicas differ": {
+			func() *appsv1.Deployment {
+				desired := current.DeepCopy()
+				replicas := *current.Spec.Replicas + 1
+				desired.Spec.Replicas = &replicas
+				return desired
+			},
+			false,
+		},
+		"equal when desired replicas are not set": {
+			func() *appsv1.Deployment {
+				desired := current.DeepCopy()
+				desired.Spec.Replicas = nil
+				return desired
+			},
+			true,
+		},
 	}
 
 	for name, tc := range testCases {
@@ -21,11 +21,15 @@ package testing
 
 import (
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	autoscalingv2listers "k8s.io/client-go/listers/autoscaling/v2"
 	corev1listers "k8s.io/client-go/listers/core/v1"
+	networkingv1listers "k8s.io/client-go/listers/networking/v1"
 	rbacv1listers "k8s.io/client-go/listers/rbac/v1"
 	"k8s.io/client-go/tools/cache"
 
@@ -105,6 +109,16 @@ func (l *Listers) GetDeploymentLister() appsv1listers.DeploymentLister {
 	return appsv1listers.NewDeploymentLister(l.IndexerFor(&appsv1.Deployment{}))
 }
 
+// GetHorizontalPodAutoscalerLister returns a lister for HorizontalPodAutoscaler objects.
+func (l *Listers) GetHorizontalPodAutoscalerLister() autoscalingv2listers.HorizontalPodAutoscalerLister {
+	return autoscalingv2listers.NewHorizontalPodAutoscalerLister(l.IndexerFor(&autoscalingv2.HorizontalPodAutoscaler{}))
+}
+
+// GetIngressLister returns a lister for Ingress objects.
+func (l *Listers) GetIngressLister() networkingv1listers.IngressLister {
+	return networkingv1listers.NewIngressLister(l.IndexerFor(&networkingv1.Ingress{}))
+}
+
 // GetSecretLister returns a lister for Secret objects.
 func (l *Listers) GetSecretLister() corev1listers.SecretLister {
 	return corev1listers.NewSecretLister(l.IndexerFor(&corev1.Secret{}))
@@ -115,6 +129,11 @@ func (l *Listers) GetConfigMapLister() corev1listers.ConfigMapLister {
 	return corev1listers.NewConfigMapLister(l.IndexerFor(&corev1.ConfigMap{}))
 }
 
+// GetNamespaceLister returns a lister for Namespace objects.
+func (l *Listers) GetNamespaceLister() corev1listers.NamespaceLister {
+	return corev1listers.NewNamespaceLister(l.IndexerFor(&corev1.Namespace{}))
+}
+
 // GetPodLister returns a lister for Pod objects.
 func (l *Listers) GetPodLister() corev1listers.PodLister {
 	return corev1listers.NewPodLister(l.IndexerFor(&corev1.Pod{}))
@@ -140,11 +159,21 @@ func (l *Listers) GetRoleBindingLister() rbacv1listers.RoleBindingLister {
 	return rbacv1listers.NewRoleBindingLister(l.IndexerFor(&rbacv1.RoleBinding{}))
 }
 
+// GetBrokerLister returns a Lister for Broker objects.
+func (l *Listers) GetBrokerLister() eventinglistersv1alpha1.BrokerLister {
+	return eventinglistersv1alpha1.NewBrokerLister(l.IndexerFor(&eventingv1alpha1.Broker{}))
+}
+
 // GetMemoryBrokerLister returns a Lister for MemoryBroker objects.
 func (l *Listers) GetMemoryBrokerLister() eventinglistersv1alpha1.MemoryBrokerLister {
 	return eventinglistersv1alpha1.NewMemoryBrokerLister(l.IndexerFor(&eventingv1alpha1.MemoryBroker{}))
 }
 
+// GetKafkaBrokerLister returns a Lister for KafkaBroker objects.
+func (l *Listers) GetKafkaBrokerLister() eventinglistersv1alpha1.KafkaBrokerLister {
+	return eventinglistersv1alpha1.NewKafkaBrokerLister(l.IndexerFor(&eventingv1alpha1.KafkaBroker{}))
+}
+
 // GetRedisBrokerLister returns a Lister for RedisBroker objects.
 func (l *Listers) GetRedisBrokerLister() eventinglistersv1alpha1.RedisBrokerLister {
 	return eventinglistersv1alpha1.NewRedisBrokerLister(l.IndexerFor(&eventingv1alpha1.RedisBroker{}))
@@ -0,0 +1,45 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	"github.com/triggermesh/triggermesh-core/pkg/reconciler/resources"
+)
+
+// BrokerOption enables further configuration of a v1alpha1.Broker.
+type BrokerOption func(*eventingv1alpha1.Broker)
+
+// NewBroker creates a v1alpha1.Broker with BrokerOption.
+func NewBroker(namespace, name string, opts ...BrokerOption) *eventingv1alpha1.Broker {
+	b := &eventingv1alpha1.Broker{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: eventingv1alpha1.BrokerSpec{},
+	}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	return b
+}
+
+func BrokerWithMetaOptions(opts ...resources.MetaOption) BrokerOption {
+	return func(b *eventingv1alpha1.Broker) {
+		for _, opt := range opts {
+			opt(&b.ObjectMeta)
+		}
+	}
+}
+
+func BrokerWithClass(class eventingv1alpha1.BrokerClass) BrokerOption {
+	return func(b *eventingv1alpha1.Broker) {
+		b.Spec.Class = class
+	}
+}
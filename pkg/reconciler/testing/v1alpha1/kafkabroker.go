@@ -0,0 +1,45 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	"github.com/triggermesh/triggermesh-core/pkg/reconciler/resources"
+)
+
+// KafkaBrokerOption enables further configuration of a v1alpha1.KafkaBroker.
+type KafkaBrokerOption func(*eventingv1alpha1.KafkaBroker)
+
+// NewKafkaBroker creates a v1alpha1.KafkaBroker with KafkaBrokerOption.
+func NewKafkaBroker(namespace, name string, opts ...KafkaBrokerOption) *eventingv1alpha1.KafkaBroker {
+	b := &eventingv1alpha1.KafkaBroker{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: eventingv1alpha1.KafkaBrokerSpec{},
+	}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	return b
+}
+
+func KafkaBrokerWithMetaOptions(opts ...resources.MetaOption) KafkaBrokerOption {
+	return func(b *eventingv1alpha1.KafkaBroker) {
+		for _, opt := range opts {
+			opt(&b.ObjectMeta)
+		}
+	}
+}
+
+func KafkaBrokerWithKafka(kafka eventingv1alpha1.Kafka) KafkaBrokerOption {
+	return func(b *eventingv1alpha1.KafkaBroker) {
+		b.Spec.Kafka = kafka
+	}
+}
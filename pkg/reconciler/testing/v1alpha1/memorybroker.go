@@ -5,6 +5,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	knapis "knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 
 	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
 	"github.com/triggermesh/triggermesh-core/pkg/reconciler/resources"
@@ -49,6 +50,20 @@ func MemoryBrokerWithStatusAddress(url string) MemoryBrokerOption {
 	}
 }
 
+func MemoryBrokerWithStatusAddressable(name, url string) MemoryBrokerOption {
+	return func(d *eventingv1alpha1.MemoryBroker) {
+
+		pu, err := knapis.ParseURL(url)
+		if err != nil {
+			panic(err)
+		}
+		d.Status.Addresses = append(d.Status.Addresses, duckv1.Addressable{
+			Name: &name,
+			URL:  pu,
+		})
+	}
+}
+
 func MemoryBrokerWithStatusCondition(typ string, status corev1.ConditionStatus, reason, msg string) MemoryBrokerOption {
 	return func(d *eventingv1alpha1.MemoryBroker) {
 
@@ -0,0 +1,39 @@
+// Copyright 2023 TriggerMesh Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+	"github.com/triggermesh/triggermesh-core/pkg/reconciler/resources"
+)
+
+// RedisBrokerOption enables further configuration of a v1alpha1.RedisBroker.
+type RedisBrokerOption func(*eventingv1alpha1.RedisBroker)
+
+// NewRedisBroker creates a v1alpha1.RedisBroker with RedisBrokerOption.
+func NewRedisBroker(namespace, name string, opts ...RedisBrokerOption) *eventingv1alpha1.RedisBroker {
+	b := &eventingv1alpha1.RedisBroker{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: eventingv1alpha1.RedisBrokerSpec{},
+	}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	return b
+}
+
+func RedisBrokerWithMetaOptions(opts ...resources.MetaOption) RedisBrokerOption {
+	return func(b *eventingv1alpha1.RedisBroker) {
+		for _, opt := range opts {
+			opt(&b.ObjectMeta)
+		}
+	}
+}
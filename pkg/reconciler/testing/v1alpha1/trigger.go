@@ -0,0 +1,71 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	knapis "knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
+)
+
+// TriggerOption enables further configuration of a v1alpha1.Trigger.
+type TriggerOption func(*eventingv1alpha1.Trigger)
+
+// NewTrigger creates a v1alpha1.Trigger that references a MemoryBroker, with TriggerOption .
+func NewTrigger(namespace, name, broker string, opts ...TriggerOption) *eventingv1alpha1.Trigger {
+	t := &eventingv1alpha1.Trigger{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: eventingv1alpha1.TriggerSpecBounded{
+			TriggerSpec: eventingv1alpha1.TriggerSpec{
+				Broker: duckv1.KReference{
+					Group: eventingv1alpha1.SchemeGroupVersion.Group,
+					Kind:  "MemoryBroker",
+					Name:  broker,
+				},
+			},
+		},
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+func TriggerWithDelivery(ds *eventingduckv1.DeliverySpec) TriggerOption {
+	return func(t *eventingv1alpha1.Trigger) {
+		t.Spec.Delivery = ds
+	}
+}
+
+func TriggerWithBrokerNamespace(namespace string) TriggerOption {
+	return func(t *eventingv1alpha1.Trigger) {
+		t.Spec.Broker.Namespace = namespace
+	}
+}
+
+func TriggerWithStatusTargetURI(url string) TriggerOption {
+	return func(t *eventingv1alpha1.Trigger) {
+		t.Status.TargetURI = parseURL(url)
+	}
+}
+
+func TriggerWithStatusDeadLetterSinkURI(url string) TriggerOption {
+	return func(t *eventingv1alpha1.Trigger) {
+		t.Status.DeadLetterSinkURI = parseURL(url)
+	}
+}
+
+func parseURL(url string) *knapis.URL {
+	pu, err := knapis.ParseURL(url)
+	if err != nil {
+		panic(err)
+	}
+	return pu
+}
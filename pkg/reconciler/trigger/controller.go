@@ -9,23 +9,24 @@ import (
 	"go.uber.org/zap"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
-	"knative.dev/pkg/kmeta"
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/resolver"
 
 	cfgInformer "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap"
+	nsinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/namespace"
 
 	"github.com/triggermesh/triggermesh-core/pkg/apis/eventing"
 	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
-	mbinformer "github.com/triggermesh/triggermesh-core/pkg/client/generated/injection/informers/eventing/v1alpha1/memorybroker"
-	rbinformer "github.com/triggermesh/triggermesh-core/pkg/client/generated/injection/informers/eventing/v1alpha1/redisbroker"
+	brinformer "github.com/triggermesh/triggermesh-core/pkg/client/generated/injection/informers/eventing/v1alpha1/broker"
 	tginformer "github.com/triggermesh/triggermesh-core/pkg/client/generated/injection/informers/eventing/v1alpha1/trigger"
 	tgreconciler "github.com/triggermesh/triggermesh-core/pkg/client/generated/injection/reconciler/eventing/v1alpha1/trigger"
+	"github.com/triggermesh/triggermesh-core/pkg/reconciler/common"
 )
 
 // NewController initializes the controller and is called by the generated code
@@ -35,14 +36,13 @@ func NewController(
 	cmw configmap.Watcher,
 ) *controller.Impl {
 	tgInformer := tginformer.Get(ctx)
-	rbInformer := rbinformer.Get(ctx)
-	mbInformer := mbinformer.Get(ctx)
 	cmInformer := cfgInformer.Get(ctx)
+	nsInformer := nsinformer.Get(ctx)
 
 	r := &Reconciler{
-		rbLister: rbInformer.Lister(),
-		mbLister: mbInformer.Lister(),
-		cmLister: cmInformer.Lister(),
+		brokerResolver: common.NewBrokerResolver(ctx),
+		cmLister:       cmInformer.Lister(),
+		nsLister:       nsInformer.Lister(),
 	}
 
 	impl := tgreconciler.NewImpl(ctx, r)
@@ -51,29 +51,25 @@ func NewController(
 
 	tgInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
 
-	// Filter brokers that are referenced by triggers.
+	// Class based Brokers are matched against the broker of their class
+	// that they control.
+	brLister := brinformer.Get(ctx).Lister()
+
+	// Filter brokers of any registered kind that are referenced by triggers.
 	filterBroker := func(obj interface{}) bool {
-		// TODO duck
-		var accessor kmeta.OwnerRefableAccessor
-		rb, ok := obj.(*eventingv1alpha1.RedisBroker)
+		b, ok := obj.(eventingv1alpha1.ReconcilableBroker)
 		if !ok {
-			mb, ok := obj.(*eventingv1alpha1.MemoryBroker)
-			if !ok {
-				return false
-			}
-			accessor = kmeta.OwnerRefableAccessor(mb)
-		} else {
-			accessor = kmeta.OwnerRefableAccessor(rb)
+			return false
 		}
 
-		tgl, err := tgInformer.Lister().Triggers(accessor.GetNamespace()).List(labels.Everything())
+		tgl, err := tgInformer.Lister().List(labels.Everything())
 		if err != nil {
 			logging.FromContext(ctx).Error("Unable to list Triggers", zap.Error(err))
 			return false
 		}
 
 		for _, tg := range tgl {
-			if tg.OwnerRefableMatchesBroker(accessor) {
+			if common.TriggerMatchesBroker(tg, b, brLister) {
 				return true
 			}
 		}
@@ -82,27 +78,19 @@ func NewController(
 	}
 
 	enqueueFromBroker := func(obj interface{}) {
-		// TODO duck
-		var accessor kmeta.OwnerRefableAccessor
-		rb, ok := obj.(*eventingv1alpha1.RedisBroker)
+		b, ok := obj.(eventingv1alpha1.ReconcilableBroker)
 		if !ok {
-			mb, ok := obj.(*eventingv1alpha1.MemoryBroker)
-			if !ok {
-				return
-			}
-			accessor = kmeta.OwnerRefableAccessor(mb)
-		} else {
-			accessor = kmeta.OwnerRefableAccessor(rb)
+			return
 		}
 
-		tgl, err := tgInformer.Lister().Triggers(accessor.GetNamespace()).List(labels.Everything())
+		tgl, err := tgInformer.Lister().List(labels.Everything())
 		if err != nil {
 			logging.FromContext(ctx).Error("Unable to list Triggers", zap.Error(err))
 			return
 		}
 
 		for _, tg := range tgl {
-			if tg.OwnerRefableMatchesBroker(accessor) {
+			if common.TriggerMatchesBroker(tg, b, brLister) {
 				impl.EnqueueKey(types.NamespacedName{
 					Name:      tg.Name,
 					Namespace: tg.Namespace,
@@ -111,6 +99,26 @@ func NewController(
 		}
 	}
 
+	// Owner references are matched against the broker that serves class based
+	// Brokers, which must be of the Broker's class and controlled by it.
+	ownerReferenceMatchesBroker := func(tg *eventingv1alpha1.Trigger, ref metav1.OwnerReference) bool {
+		if !tg.ReferencesClassBroker() {
+			return tg.OwnerReferenceMatchesBroker(ref)
+		}
+
+		b, err := brLister.Brokers(tg.BrokerNamespace()).Get(tg.Spec.Broker.Name)
+		if err != nil {
+			return false
+		}
+
+		served, err := r.brokerResolver.Resolve(eventingv1alpha1.Kind(string(b.Spec.Class)), b.Namespace, b.Name)
+		if err != nil {
+			return false
+		}
+
+		return tg.OwnerReferenceMatchesClassBroker(ref, b, served)
+	}
+
 	filterConfigMapBroker := func(obj interface{}) bool {
 		cm, ok := obj.(*corev1.ConfigMap)
 		if !ok {
@@ -124,9 +132,9 @@ func NewController(
 			return false
 		}
 
-		// Iterate all triggers at the namespace and select those that are applied
-		// to the ConfigMap broker(s).
-		tgs, err := tgInformer.Lister().Triggers(cm.Namespace).List(labels.Everything())
+		// Iterate all triggers and select those that are applied to the
+		// ConfigMap broker(s), which might live at other namespaces.
+		tgs, err := tgInformer.Lister().List(labels.Everything())
 		if err != nil {
 			logging.FromContext(ctx).Error("Unable to list Triggers", zap.Error(err))
 			return false
@@ -135,7 +143,7 @@ func NewController(
 		// Finding one will make the filter pass.
 		for i := range tgs {
 			for j := range obs {
-				if tgs[i].OwnerReferenceMatchesBroker(obs[j]) {
+				if tgs[i].BrokerNamespace() == cm.Namespace && ownerReferenceMatchesBroker(tgs[i], obs[j]) {
 					return true
 				}
 			}
@@ -158,20 +166,17 @@ func NewController(
 			return
 		}
 
-		// Iterate all triggers at the namespace and select those that are applied
-		// to the ConfigMap broker(s).
-		tgs, err := tgInformer.Lister().Triggers(cm.Namespace).List(labels.Everything())
+		// Iterate all triggers and select those that are applied to the
+		// ConfigMap broker(s), which might live at other namespaces.
+		tgs, err := tgInformer.Lister().List(labels.Everything())
 		if err != nil {
 			logging.FromContext(ctx).Error("Unable to list Triggers", zap.Error(err))
 			return
 		}
 
-		// Iterate all triggers at the namespace and select those that are applied
-		// to the ConfigMap broker(s).
-		// Finding one will make the filter pass.
 		for i := range tgs {
 			for j := range obs {
-				if tgs[i].OwnerReferenceMatchesBroker(obs[j]) {
+				if tgs[i].BrokerNamespace() == cm.Namespace && ownerReferenceMatchesBroker(tgs[i], obs[j]) {
 					impl.EnqueueKey(types.NamespacedName{
 						Name:      tgs[i].Name,
 						Namespace: tgs[i].Namespace,
@@ -182,12 +187,7 @@ func NewController(
 		}
 	}
 
-	rbInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
-		FilterFunc: filterBroker,
-		Handler:    controller.HandleAll(enqueueFromBroker),
-	})
-
-	mbInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+	r.brokerResolver.AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: filterBroker,
 		Handler:    controller.HandleAll(enqueueFromBroker),
 	})
@@ -197,5 +197,26 @@ func NewController(
 		Handler:    controller.HandleAll(enqueueFromConfigMapBroker),
 	})
 
+	// Namespace labels decide whether Triggers are allowed to subscribe to
+	// brokers at other namespaces.
+	nsInformer.Informer().AddEventHandler(controller.HandleAll(func(obj interface{}) {
+		ns, ok := obj.(*corev1.Namespace)
+		if !ok {
+			return
+		}
+
+		tgs, err := tgInformer.Lister().Triggers(ns.Name).List(labels.Everything())
+		if err != nil {
+			logging.FromContext(ctx).Error("Unable to list Triggers", zap.Error(err))
+			return
+		}
+
+		for _, tg := range tgs {
+			if tg.BrokerNamespace() != ns.Name {
+				impl.Enqueue(tg)
+			}
+		}
+	}))
+
 	return impl
 }
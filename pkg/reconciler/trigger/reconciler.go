@@ -12,6 +12,7 @@ import (
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
@@ -21,65 +22,56 @@ import (
 	"github.com/triggermesh/brokers/pkg/status"
 
 	eventingv1alpha1 "github.com/triggermesh/triggermesh-core/pkg/apis/eventing/v1alpha1"
-	eventingv1alpha1listers "github.com/triggermesh/triggermesh-core/pkg/client/generated/listers/eventing/v1alpha1"
 	"github.com/triggermesh/triggermesh-core/pkg/reconciler/common"
 )
 
 type Reconciler struct {
-	// TODO duck brokers
-	rbLister    eventingv1alpha1listers.RedisBrokerLister
-	mbLister    eventingv1alpha1listers.MemoryBrokerLister
-	cmLister    corev1listers.ConfigMapLister
-	uriResolver *resolver.URIResolver
+	brokerResolver common.BrokerResolver
+	cmLister       corev1listers.ConfigMapLister
+	nsLister       corev1listers.NamespaceLister
+	uriResolver    *resolver.URIResolver
 }
 
 func (r *Reconciler) ReconcileKind(ctx context.Context, t *eventingv1alpha1.Trigger) pkgreconciler.Event {
+	// Triggers created before the webhook was deployed might not be defaulted.
+	t.SetDefaults(ctx)
+
 	b, err := r.resolveBroker(ctx, t)
 	if err != nil {
 		return err
 	}
 
-	if err := r.resolveTarget(ctx, t); err != nil {
-		return err
+	// Some brokers report the events waiting to be delivered to each Trigger.
+	if cp, ok := b.(eventingv1alpha1.TriggerConsumerStatusProvider); ok {
+		t.Status.Consumer = cp.GetTriggerConsumerStatus(t.ConfigKey())
 	}
 
-	if err := r.resolveDLS(ctx, t); err != nil {
-		return err
+	// Target and DLS are resolved independently, a failure resolving one of them
+	// should not prevent the broker from using the other.
+	targetErr := r.resolveTarget(ctx, t)
+	dlsErr := r.resolveDLS(ctx, t)
+	switch {
+	case targetErr != nil:
+		return targetErr
+	case dlsErr != nil:
+		return dlsErr
 	}
 
 	return r.reconcileStatusConfigMap(ctx, t, b)
 }
 
 func (r *Reconciler) resolveBroker(ctx context.Context, t *eventingv1alpha1.Trigger) (eventingv1alpha1.ReconcilableBroker, pkgreconciler.Event) {
-	// TODO duck
-	// TODO move to webhook
-	switch {
-	case t.Spec.Broker.Group == "":
-		t.Spec.Broker.Group = eventingv1alpha1.SchemeGroupVersion.Group
-	case t.Spec.Broker.Group != eventingv1alpha1.SchemeGroupVersion.Group:
-		return nil, controller.NewPermanentError(fmt.Errorf("not supported Broker Group %q", t.Spec.Broker.Group))
+	gk := schema.GroupKind{Group: t.Spec.Broker.Group, Kind: t.Spec.Broker.Kind}
+	if !r.brokerResolver.IsRegistered(gk) {
+		return nil, controller.NewPermanentError(fmt.Errorf("not supported Broker %q", gk))
 	}
 
-	var rb *eventingv1alpha1.RedisBroker
-	if t.Spec.Broker.Kind == rb.GetGroupVersionKind().Kind {
-		return r.resolveRedisBroker(ctx, t)
-	}
-
-	var mb *eventingv1alpha1.MemoryBroker
-	if t.Spec.Broker.Kind != mb.GetGroupVersionKind().Kind {
-		return nil, controller.NewPermanentError(fmt.Errorf("not supported Broker Kind %q", t.Spec.Broker.Kind))
-	}
-
-	return r.resolveMemoryBroker(ctx, t)
-}
-
-func (r *Reconciler) resolveRedisBroker(ctx context.Context, t *eventingv1alpha1.Trigger) (eventingv1alpha1.ReconcilableBroker, pkgreconciler.Event) {
-	rb, err := r.rbLister.RedisBrokers(t.Namespace).Get(t.Spec.Broker.Name)
+	b, err := r.brokerResolver.Resolve(gk, t.BrokerNamespace(), t.Spec.Broker.Name)
 	if err != nil {
 		if apierrs.IsNotFound(err) {
-			logging.FromContext(ctx).Errorw(fmt.Sprintf("Trigger %s/%s references non existing broker %q", t.Namespace, t.Name, t.Spec.Broker.Name))
+			logging.FromContext(ctx).Errorf("Trigger %s/%s references non existing broker %q", t.Namespace, t.Name, t.Spec.Broker.Name)
 			t.Status.MarkBrokerFailed(common.ReasonBrokerDoesNotExist, "Broker %q does not exist", t.Spec.Broker.Name)
-			// No need to requeue, we will be notified when if broker is created.
+			// No need to requeue, we will be notified when broker is created.
 			return nil, controller.NewPermanentError(err)
 		}
 
@@ -88,39 +80,28 @@ func (r *Reconciler) resolveRedisBroker(ctx context.Context, t *eventingv1alpha1
 			"Failed to get broker for trigger %s/%s: %w", t.Namespace, t.Name, err)
 	}
 
-	t.Status.PropagateBrokerCondition(rb.Status.GetTopLevelCondition())
-
-	// No need to requeue, we'll get requeued when broker changes status.
-	if !rb.IsReady() {
-		logging.FromContext(ctx).Errorw(fmt.Sprintf("Trigger %s/%s references non ready broker %q", t.Namespace, t.Name, t.Spec.Broker.Name))
-	}
-
-	return rb, nil
-}
-
-func (r *Reconciler) resolveMemoryBroker(ctx context.Context, t *eventingv1alpha1.Trigger) (eventingv1alpha1.ReconcilableBroker, pkgreconciler.Event) {
-	mb, err := r.mbLister.MemoryBrokers(t.Namespace).Get(t.Spec.Broker.Name)
+	allowed, err := common.IsTriggerNamespaceAllowed(b, t.Namespace, r.nsLister)
 	if err != nil {
-		if apierrs.IsNotFound(err) {
-			logging.FromContext(ctx).Errorf("Trigger %s/%s references non existing broker %q", t.Namespace, t.Name, t.Spec.Broker.Name)
-			t.Status.MarkBrokerFailed(common.ReasonBrokerDoesNotExist, "Broker %q does not exist", t.Spec.Broker.Name)
-			// No need to requeue, we will be notified when broker is created.
-			return nil, controller.NewPermanentError(err)
-		}
-
-		t.Status.MarkBrokerFailed(common.ReasonFailedBrokerGet, "Failed to get broker %q : %s", t.Spec.Broker, err)
+		t.Status.MarkBrokerFailed(common.ReasonFailedBrokerGet, "Failed to check if namespace %q is allowed by broker %q: %s", t.Namespace, t.Spec.Broker.Name, err)
 		return nil, pkgreconciler.NewEvent(corev1.EventTypeWarning, common.ReasonFailedBrokerGet,
-			"Failed to get broker for trigger %s/%s: %w", t.Namespace, t.Name, err)
+			"Failed to check if namespace %q is allowed by broker %s/%s: %w", t.Namespace, t.BrokerNamespace(), t.Spec.Broker.Name, err)
+	}
+	if !allowed {
+		t.Status.MarkBrokerFailed(common.ReasonTriggerNamespaceNotAllowed,
+			"Broker %s/%s does not allow Triggers from namespace %q", t.BrokerNamespace(), t.Spec.Broker.Name, t.Namespace)
+		// No need to requeue, we will be notified when the broker or the namespace change.
+		return nil, controller.NewPermanentError(fmt.Errorf("broker %s/%s does not allow Triggers from namespace %q",
+			t.BrokerNamespace(), t.Spec.Broker.Name, t.Namespace))
 	}
 
-	t.Status.PropagateBrokerCondition(mb.Status.GetTopLevelCondition())
+	t.Status.PropagateBrokerCondition(b.GetReconcilableBrokerStatus().GetTopLevelCondition())
 
 	// No need to requeue, we'll get requeued when broker changes status.
-	if !mb.IsReady() {
-		logging.FromContext(ctx).Errorw(fmt.Sprintf("Trigger %s/%s references non ready broker %q", t.Namespace, t.Name, t.Spec.Broker.Name))
+	if !b.IsReady() {
+		logging.FromContext(ctx).Errorf("Trigger %s/%s references non ready broker %q", t.Namespace, t.Name, t.Spec.Broker.Name)
 	}
 
-	return mb, nil
+	return b, nil
 }
 
 func (r *Reconciler) resolveTarget(ctx context.Context, t *eventingv1alpha1.Trigger) pkgreconciler.Event {
@@ -133,9 +114,14 @@ func (r *Reconciler) resolveTarget(ctx context.Context, t *eventingv1alpha1.Trig
 	targetURI, err := r.uriResolver.URIFromDestinationV1(ctx, t.Spec.Target, t)
 	if err != nil {
 		logging.FromContext(ctx).Errorw("Unable to get the target's URI", zap.Error(err))
-		t.Status.MarkTargetResolvedFailed("Unable to get the target's URI", "%v", err)
 		t.Status.TargetURI = nil
-		return pkgreconciler.NewEvent(corev1.EventTypeWarning, common.ReasonFailedResolveReference,
+
+		reason := common.ReasonFailedResolveTarget
+		if apierrs.IsNotFound(err) {
+			reason = common.ReasonTargetDoesNotExist
+		}
+		t.Status.MarkTargetResolvedFailed(reason, "Unable to get the target's URI: %v", err)
+		return pkgreconciler.NewEvent(corev1.EventTypeWarning, reason,
 			"Failed to get target's URI: %w", err)
 	}
 
@@ -161,9 +147,14 @@ func (r *Reconciler) resolveDLS(ctx context.Context, t *eventingv1alpha1.Trigger
 	dlsURI, err := r.uriResolver.URIFromDestinationV1(ctx, *t.Spec.Delivery.DeadLetterSink, t)
 	if err != nil {
 		logging.FromContext(ctx).Errorw("Unable to get the dead letter sink's URI", zap.Error(err))
-		t.Status.MarkDeadLetterSinkResolvedFailed("Unable to get the dead letter sink's URI", "%v", err)
-		t.Status.TargetURI = nil
-		return pkgreconciler.NewEvent(corev1.EventTypeWarning, common.ReasonFailedResolveReference,
+		t.Status.DeadLetterSinkURI = nil
+
+		reason := common.ReasonFailedResolveDeadLetterSink
+		if apierrs.IsNotFound(err) {
+			reason = common.ReasonDeadLetterSinkDoesNotExist
+		}
+		t.Status.MarkDeadLetterSinkResolvedFailed(reason, "Unable to get the dead letter sink's URI: %v", err)
+		return pkgreconciler.NewEvent(corev1.EventTypeWarning, reason,
 			"Failed to get dead letter sink's URI: %w", err)
 	}
 
@@ -176,7 +167,7 @@ func (r *Reconciler) resolveDLS(ctx context.Context, t *eventingv1alpha1.Trigger
 func (r *Reconciler) reconcileStatusConfigMap(ctx context.Context, t *eventingv1alpha1.Trigger, b eventingv1alpha1.ReconcilableBroker) pkgreconciler.Event {
 	configMapName := common.GetBrokerConfigMapName(b)
 
-	cm, err := r.cmLister.ConfigMaps(t.Namespace).Get(configMapName)
+	cm, err := r.cmLister.ConfigMaps(b.GetObjectMeta().GetNamespace()).Get(configMapName)
 	if err != nil {
 		if apierrs.IsNotFound(err) {
 			logging.FromContext(ctx).Errorf("Trigger %s/%s could not find the Status ConfigMap for the referenced broker %q", t.Namespace, t.Name, configMapName)
@@ -213,7 +204,7 @@ func (r *Reconciler) summarizeStatus(t *eventingv1alpha1.Trigger, sts map[string
 	// Iterate all nodes and take note of the status for this trigger
 	var temp status.SubscriptionStatusChoice
 	for instance, st := range sts {
-		subs, ok := st.Subscriptions[t.Name]
+		subs, ok := st.Subscriptions[t.ConfigKey()]
 		if !ok {
 			continue
 		}